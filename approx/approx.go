@@ -0,0 +1,141 @@
+// Package approx measures how well rational values approximate a
+// sqrt.Number.
+//
+// Because a sqrt.Number generally has an infinite number of digits, every
+// function in this package accepts a maxDigits parameter that limits how
+// many significant digits of the Number it examines. The metrics returned
+// are guaranteed to hold for the exact value of the Number, not just for
+// the examined digits.
+package approx
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/keep94/sqrt"
+)
+
+var (
+	ten = big.NewInt(10)
+)
+
+// Metrics describes how well a candidate approximates a Number.
+type Metrics struct {
+
+	// MatchingDigits is the number of leading significant digits that the
+	// candidate and the Number have in common. MatchingDigits is 0 if the
+	// candidate and the Number have different exponents. It never exceeds
+	// maxDigits.
+	MatchingDigits int
+
+	// DecimalPlaces is the largest d such that the absolute error is
+	// guaranteed to be less than 10^-d. DecimalPlaces can be negative.
+	// DecimalPlaces is math.MaxInt if the candidate equals the Number
+	// exactly.
+	DecimalPlaces int
+
+	// RelativeDigits is the largest d such that the relative error is
+	// guaranteed to be less than 10^-d. RelativeDigits can be negative.
+	// RelativeDigits is math.MaxInt if the candidate equals the Number
+	// exactly and 0 if the Number is zero but the candidate is not.
+	RelativeDigits int
+}
+
+// Measure returns how well candidate approximates n examining no more
+// than maxDigits significant digits of n. Measure panics if maxDigits is
+// negative.
+func Measure(n sqrt.Number, candidate *big.Rat, maxDigits int) Metrics {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	digits := sqrt.AsString(n.WithSignificant(maxDigits))
+	lo := ratFromDigits(digits, n.Exponent())
+	hi := lo
+	if len(digits) == maxDigits {
+		hi = new(big.Rat).Add(lo, pow10Rat(n.Exponent()-len(digits)))
+	}
+
+	// The absolute error can be no more than errHi.
+	var errLo, errHi big.Rat
+	errLo.Sub(lo, candidate).Abs(&errLo)
+	errHi.Sub(hi, candidate).Abs(&errHi)
+	if errLo.Cmp(&errHi) > 0 {
+		errHi.Set(&errLo)
+	}
+	result := Metrics{
+		MatchingDigits: matchingDigits(digits, n.Exponent(), candidate),
+	}
+	if errHi.Sign() == 0 {
+		result.DecimalPlaces = math.MaxInt
+		result.RelativeDigits = math.MaxInt
+		return result
+	}
+	result.DecimalPlaces = -floorLog10(&errHi) - 1
+	if lo.Sign() > 0 {
+		var relErr big.Rat
+		relErr.Quo(&errHi, lo)
+		result.RelativeDigits = -floorLog10(&relErr) - 1
+	}
+	return result
+}
+
+// Table returns the Metrics of each candidate in turn so that callers can
+// see how a sequence of approximations converges to n. Table examines no
+// more than maxDigits significant digits of n. Table panics if maxDigits
+// is negative.
+func Table(n sqrt.Number, candidates []*big.Rat, maxDigits int) []Metrics {
+	result := make([]Metrics, len(candidates))
+	for i, candidate := range candidates {
+		result[i] = Measure(n, candidate, maxDigits)
+	}
+	return result
+}
+
+// matchingDigits returns how many leading digits of the mantissa digits
+// with exponent exp have in common with candidate.
+func matchingDigits(digits string, exp int, candidate *big.Rat) int {
+	if candidate.Sign() <= 0 || len(digits) == 0 {
+		return 0
+	}
+	if floorLog10(candidate)+1 != exp {
+		return 0
+	}
+
+	// scaled = floor(candidate * 10^(len(digits) - exp))
+	var scaled big.Rat
+	scaled.Mul(candidate, pow10Rat(len(digits)-exp))
+	candidateDigits := new(big.Int).Quo(scaled.Num(), scaled.Denom()).String()
+	result := 0
+	for result < len(digits) && digits[result] == candidateDigits[result] {
+		result++
+	}
+	return result
+}
+
+// floorLog10 returns the floor of the base 10 logarithm of x. x must be
+// positive.
+func floorLog10(x *big.Rat) int {
+	result := len(x.Num().String()) - len(x.Denom().String())
+	if x.Cmp(pow10Rat(result)) < 0 {
+		result--
+	}
+	return result
+}
+
+func ratFromDigits(digits string, exp int) *big.Rat {
+	if digits == "" {
+		return new(big.Rat)
+	}
+	mantissa, _ := new(big.Int).SetString(digits, 10)
+	result := new(big.Rat).SetInt(mantissa)
+	return result.Mul(result, pow10Rat(exp-len(digits)))
+}
+
+func pow10Rat(exp int) *big.Rat {
+	if exp >= 0 {
+		return new(big.Rat).SetInt(
+			new(big.Int).Exp(ten, big.NewInt(int64(exp)), nil))
+	}
+	return new(big.Rat).SetFrac(
+		big.NewInt(1), new(big.Int).Exp(ten, big.NewInt(int64(-exp)), nil))
+}
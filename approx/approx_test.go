@@ -0,0 +1,75 @@
+package approx_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/keep94/sqrt"
+	"github.com/keep94/sqrt/approx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	convergents := []*big.Rat{
+		big.NewRat(1, 1),
+		big.NewRat(3, 2),
+		big.NewRat(7, 5),
+		big.NewRat(17, 12),
+		big.NewRat(41, 29),
+		big.NewRat(99, 70),
+		big.NewRat(577, 408),
+		big.NewRat(665857, 470832),
+	}
+	expected := []approx.Metrics{
+		{MatchingDigits: 1, DecimalPlaces: 0, RelativeDigits: 0},
+		{MatchingDigits: 1, DecimalPlaces: 1, RelativeDigits: 1},
+		{MatchingDigits: 2, DecimalPlaces: 1, RelativeDigits: 1},
+		{MatchingDigits: 3, DecimalPlaces: 2, RelativeDigits: 2},
+		{MatchingDigits: 3, DecimalPlaces: 3, RelativeDigits: 3},
+		{MatchingDigits: 5, DecimalPlaces: 4, RelativeDigits: 4},
+		{MatchingDigits: 6, DecimalPlaces: 5, RelativeDigits: 5},
+		{MatchingDigits: 12, DecimalPlaces: 11, RelativeDigits: 11},
+	}
+	assert.Equal(t, expected, approx.Table(sqrt.Sqrt(2), convergents, 40))
+}
+
+func TestMeasureLimitedDigits(t *testing.T) {
+	m := approx.Measure(sqrt.Sqrt(2), big.NewRat(665857, 470832), 5)
+	assert.Equal(
+		t,
+		approx.Metrics{MatchingDigits: 5, DecimalPlaces: 4, RelativeDigits: 4},
+		m)
+}
+
+func TestMeasureExact(t *testing.T) {
+	m := approx.Measure(sqrt.Sqrt(4), big.NewRat(2, 1), 10)
+	assert.Equal(
+		t,
+		approx.Metrics{
+			MatchingDigits: 1,
+			DecimalPlaces:  math.MaxInt,
+			RelativeDigits: math.MaxInt},
+		m)
+}
+
+func TestMeasureExponentMismatch(t *testing.T) {
+	m := approx.Measure(sqrt.Sqrt(2), big.NewRat(1, 2), 10)
+	assert.Equal(t, 0, m.MatchingDigits)
+	assert.Equal(t, 0, m.DecimalPlaces)
+	m = approx.Measure(sqrt.Sqrt(2), big.NewRat(-3, 2), 10)
+	assert.Equal(t, 0, m.MatchingDigits)
+	assert.Equal(t, -1, m.DecimalPlaces)
+}
+
+func TestMeasureZero(t *testing.T) {
+	m := approx.Measure(sqrt.Sqrt(0), big.NewRat(1, 200), 10)
+	assert.Equal(
+		t,
+		approx.Metrics{MatchingDigits: 0, DecimalPlaces: 2, RelativeDigits: 0},
+		m)
+}
+
+func TestMeasurePanics(t *testing.T) {
+	assert.Panics(t, func() { approx.Measure(sqrt.Sqrt(2), big.NewRat(1, 1), -1) })
+}
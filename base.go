@@ -0,0 +1,179 @@
+package sqrt
+
+import (
+	"math"
+	"math/big"
+)
+
+const (
+	kLowerDigitChars = "0123456789abcdef"
+	kUpperDigitChars = "0123456789ABCDEF"
+
+	// kBaseMaxExtraDigits is how many significant digits beyond those
+	// that should decide a digit in another base baseGenerator examines
+	// before giving up on deciding it.
+	kBaseMaxExtraDigits = 1000
+)
+
+// newBaseGenerator returns a Generator for the value of n in the given
+// base. The digits and exponent that the returned Generator generates are
+// in base rather than in base 10. base must be between 2 and 16. If exact
+// is not nil, it is the exact value of n, and the returned Generator
+// converts it directly instead of refining the digits of n. exact must be
+// given when n has infinitely many digits and its value is a power of
+// base such as 0.999... in base 10 because refining never finishes for
+// such values.
+func newBaseGenerator(n *numberPart, exact *big.Rat, base int) Generator {
+	return &baseGenerator{n: n, exact: exact, base: big.NewInt(int64(base))}
+}
+
+type baseGenerator struct {
+	n     *numberPart
+	exact *big.Rat
+	base  *big.Int
+}
+
+func (g *baseGenerator) Generate() (func() int, int) {
+	if g.n.IsZero() {
+		return func() int { return -1 }, 0
+	}
+	if g.exact != nil {
+		return ratDigitsInBase(g.exact, g.base)
+	}
+	sigDigits := kIntervalInitialPrecision
+	for {
+		known, exp, exact := g.prefix(sigDigits)
+		if exact == nil && g.pastLimit(sigDigits, 0) {
+			exact = g.lowerBound(sigDigits)
+		}
+		if exact != nil {
+			return ratDigitsInBase(exact, g.base)
+		}
+		if known != "" {
+			return g.digits(sigDigits, known), exp
+		}
+		sigDigits *= 2
+	}
+}
+
+func (g *baseGenerator) digits(sigDigits int, known string) func() int {
+	index := 0
+	var exactDigits func() int
+	return func() int {
+		if exactDigits != nil {
+			return exactDigits()
+		}
+		for index >= len(known) {
+			sigDigits *= 2
+			prefix, _, exact := g.prefix(sigDigits)
+			if exact == nil && g.pastLimit(sigDigits, index) {
+				exact = g.lowerBound(sigDigits)
+			}
+			if exact != nil {
+				exactDigits, _ = ratDigitsInBase(exact, g.base)
+				for range index {
+					exactDigits()
+				}
+				return exactDigits()
+			}
+			if len(prefix) > len(known) {
+				known = prefix
+			}
+		}
+		result := digitValue(known[index])
+		index++
+		return result
+	}
+}
+
+// pastLimit returns true if sigDigits is more than kBaseMaxExtraDigits
+// beyond the significant digits of n that determine the digit in base
+// g.base at index. Past that limit, the digits of n likely never decide
+// that digit, as when n is 0.4999... but does not know that its 9s
+// repeat, so baseGenerator settles for the digits of n truncated to
+// sigDigits. These are the same digits that printing n in base 10 shows.
+func (g *baseGenerator) pastLimit(sigDigits, index int) bool {
+	needed := float64(index+1) * log2(g.base) / math.Log2(10)
+	return float64(sigDigits) > needed+kBaseMaxExtraDigits
+}
+
+// lowerBound returns the exact value of n truncated to sigDigits
+// significant digits.
+func (g *baseGenerator) lowerBound(sigDigits int) *big.Rat {
+	mantissa, count := mantissaInt(&FiniteNumber{g.n.withEnd(sigDigits)})
+	return ratTimesPow10(mantissa, g.n.exponent-count)
+}
+
+// prefix returns the leading digits of n in base g.base that the first
+// sigDigits significant digits of n determine along with the exponent in
+// base g.base. If prefix cannot determine the exponent, it returns the
+// empty string. If n has fewer than sigDigits significant digits, prefix
+// returns the exact value of n as exact.
+func (g *baseGenerator) prefix(sigDigits int) (
+	known string, exp int, exact *big.Rat) {
+	truncated := g.n.withEnd(sigDigits)
+	mantissa, count := mantissaInt(&FiniteNumber{truncated})
+	lo := ratTimesPow10(mantissa, g.n.exponent-count)
+	if count < sigDigits {
+		return "", 0, lo
+	}
+	hi := ratTimesPow10(mantissa.Add(mantissa, one), g.n.exponent-count)
+
+	// Scale by base^places so that lo and hi have roughly sigDigits
+	// significant digits to the left of the point.
+	places := int(math.Ceil(float64(sigDigits-g.n.exponent) *
+		math.Log2(10) / log2(g.base)))
+	loStr := scaledFloor(lo, g.base, places).Text(int(g.base.Int64()))
+	hiStr := scaledFloor(hi, g.base, places).Text(int(g.base.Int64()))
+	if len(loStr) != len(hiStr) || loStr == "0" {
+		return "", 0, nil
+	}
+	return commonPrefix(loStr, hiStr), len(loStr) - places, nil
+}
+
+// scaledFloor returns the floor of x * base^places. x must be
+// non-negative.
+func scaledFloor(x *big.Rat, base *big.Int, places int) *big.Int {
+	scale := new(big.Int).Exp(base, big.NewInt(int64(max(places, -places))), nil)
+	num := new(big.Int).Set(x.Num())
+	denom := new(big.Int).Set(x.Denom())
+	if places >= 0 {
+		num.Mul(num, scale)
+	} else {
+		denom.Mul(denom, scale)
+	}
+	return num.Quo(num, denom)
+}
+
+// ratDigitsInBase returns the digits and exponent of x in base. x must be
+// positive.
+func ratDigitsInBase(x *big.Rat, base *big.Int) (func() int, int) {
+	num := new(big.Int).Set(x.Num())
+	denom := new(big.Int).Set(x.Denom())
+	exp := 0
+	for num.Cmp(denom) >= 0 {
+		exp++
+		denom.Mul(denom, base)
+	}
+	var scaled big.Int
+	for scaled.Mul(num, base).Cmp(denom) < 0 {
+		exp--
+		num.Set(&scaled)
+	}
+	var digit big.Int
+	return func() int {
+		if num.Sign() == 0 {
+			return -1
+		}
+		num.Mul(num, base)
+		digit.DivMod(num, denom, num)
+		return int(digit.Int64())
+	}, exp
+}
+
+func digitValue(ch byte) int {
+	if ch >= 'a' {
+		return int(ch-'a') + 10
+	}
+	return int(ch - '0')
+}
@@ -0,0 +1,144 @@
+package sqrt
+
+import (
+	"math"
+	"math/big"
+)
+
+// kBigFloatMaxRatExp is the largest binary exponent, in absolute value,
+// for which a big.Float radican is converted to a big.Rat exactly.
+// Beyond it, 2^exp is too big to compute, so the root is computed from
+// the mantissa alone.
+const kBigFloatMaxRatExp = 1 << 16
+
+// kBigFloatGuardBits is how many bits of precision beyond what the
+// requested digits need nRootBigFloat uses. It covers the rounding error
+// of computing 5^m by repeated squaring.
+const kBigFloatGuardBits = 128
+
+// SqrtBigFloat returns the square root of radican. Because Number can only
+// hold positive results, SqrtBigFloat panics if radican is negative or
+// infinite. SqrtBigFloat never computes 2 raised to the binary exponent
+// of radican, so it uses little memory even when that exponent is near
+// big.MaxExp. However, when that exponent is huge and the square root
+// has a finite number of digits, computing past its last digit never
+// finishes.
+func SqrtBigFloat(radican *big.Float) Number {
+	return nRootBigFloat(radican, 2)
+}
+
+// CubeRootBigFloat returns the cube root of radican. Because Number can only
+// hold positive results, CubeRootBigFloat panics if radican is negative or
+// infinite. Like SqrtBigFloat, CubeRootBigFloat uses little memory even
+// when the binary exponent of radican is huge.
+func CubeRootBigFloat(radican *big.Float) Number {
+	return nRootBigFloat(radican, 3)
+}
+
+func nRootBigFloat(radican *big.Float, n int) Number {
+	if radican.IsInf() {
+		panic("radican must be finite")
+	}
+	if radican.Sign() < 0 {
+		panic("radican must be non-negative")
+	}
+	if radican.Sign() == 0 {
+		return &FiniteNumber{}
+	}
+	var mant big.Float
+	exp := radican.MantExp(&mant)
+	if exp >= -kBigFloatMaxRatExp && exp <= kBigFloatMaxRatExp {
+		r, _ := radican.Rat(nil)
+		return nRootFrac(r.Num(), r.Denom(), n)
+	}
+
+	// radican = base * 2^(n*q) where base = mant * 2^(exp-n*q) is small.
+	q := exp / n
+	if exp%n < 0 {
+		q--
+	}
+	base := new(big.Float).SetMantExp(&mant, exp-n*q)
+
+	// Scale the root by 10^-estimate so that the interval generator works
+	// with numbers of reasonable size.
+	mantFloat, _ := mant.Float64()
+	estimate := int(math.Floor(
+		(float64(exp)*math.Log10(2) + math.Log10(mantFloat)) / float64(n)))
+	digits, digitsExp := newIntervalGenerator(func(k int) (lo, hi *big.Int) {
+		prec := uint(4*max(k, 0)) + kBigFloatGuardBits
+		return floatRootBounds(base, q, n, k-estimate, prec)
+	}).Generate()
+	return newNumber(digits, digitsExp+estimate)
+}
+
+// floatRootBounds returns lo and hi such that
+// lo <= nthroot(base) * 2^q * 10^j <= hi computing with prec bits of
+// precision. base must be positive.
+func floatRootBounds(
+	base *big.Float, q, n, j int, prec uint) (lo, hi *big.Int) {
+	lo = floorRoot(scaledPower(base, q, n, j, prec, false), n, false)
+	hi = floorRoot(scaledPower(base, q, n, j, prec, true), n, true)
+	return
+}
+
+// scaledPower returns base * 2^(n*q) * 10^(n*j) rounded down to an
+// integer or, if up is true, rounded up.
+func scaledPower(
+	base *big.Float, q, n, j int, prec uint, up bool) *big.Int {
+	mode := big.ToNegativeInf
+	if up {
+		mode = big.ToPositiveInf
+	}
+
+	// 2^(n*q) * 10^(n*j) = 5^(n*j) * 2^(n*q+n*j)
+	x := powFive(n*j, prec, up)
+	x.SetMode(mode).Mul(x, base)
+	x.SetMantExp(x, n*q+n*j)
+	result, accuracy := x.Int(nil)
+	if up && accuracy == big.Below {
+		result.Add(result, one)
+	}
+	return result
+}
+
+// powFive returns 5^m rounded down or, if up is true, rounded up to prec
+// bits.
+func powFive(m int, prec uint, up bool) *big.Float {
+	mode := big.ToNegativeInf
+	if up {
+		mode = big.ToPositiveInf
+	}
+	result := new(big.Float).SetPrec(prec).SetMode(mode).SetInt64(1)
+	if m < 0 {
+		return result.Quo(result, powFive(-m, prec, !up))
+	}
+	power := new(big.Float).SetPrec(prec).SetMode(mode).SetInt64(5)
+	for ; m > 0; m >>= 1 {
+		if m&1 == 1 {
+			result.Mul(result, power)
+		}
+		power.Mul(power, power)
+	}
+	return result
+}
+
+// floorRoot returns the floor of the nth root of x or, if up is true, the
+// ceiling. x must be non-negative.
+func floorRoot(x *big.Int, n int, up bool) *big.Int {
+	if x.Sign() == 0 {
+		return x
+	}
+	var root *big.Int
+	exact := false
+	if n == 2 {
+		root = new(big.Int).Sqrt(x)
+		var square big.Int
+		exact = square.Mul(root, root).Cmp(x) == 0
+	} else {
+		root, exact = intRoot(x, n)
+	}
+	if up && !exact {
+		root.Add(root, one)
+	}
+	return root
+}
@@ -0,0 +1,173 @@
+package sqrt
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b, or 1 if
+// a > b along with true. Cmp examines no more than maxDigits significant
+// digits of a and b. If a and b agree on their first maxDigits digits,
+// Cmp returns 0, false unless both a and b are known to end within those
+// digits. Cmp panics if maxDigits is negative.
+func Cmp(a, b Number, maxDigits int) (int, bool) {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	switch {
+	case a.IsZero() && b.IsZero():
+		return 0, true
+	case a.IsZero():
+		return -1, true
+	case b.IsZero():
+		return 1, true
+	case a.Exponent() != b.Exponent():
+		return cmp.Compare(a.Exponent(), b.Exponent()), true
+	}
+	_, result, ok := firstDifference(
+		a.impl().mantissa, b.impl().mantissa, maxDigits)
+	return result, ok
+}
+
+// firstDifference compares the digits of a and b examining no more than
+// maxDigits of each. A missing digit counts as 0. If a and b differ,
+// firstDifference returns the position of the first difference, -1 or 1,
+// and true. If a and b have the same digits, firstDifference returns the
+// position just past their last digit, 0, and true. Otherwise
+// firstDifference returns maxDigits, 0, and false.
+func firstDifference(a, b mantissa, maxDigits int) (int, int, bool) {
+	var aData, bData digitData
+	for i := 0; i <= maxDigits; i++ {
+		if i >= aData.Len() {
+			aData = a.Data(i)
+		}
+		if i >= bData.Len() {
+			bData = b.Data(i)
+		}
+		aDone, bDone := i >= aData.Len(), i >= bData.Len()
+		if aDone && bDone {
+			return i, 0, true
+		}
+		if i == maxDigits {
+			break
+		}
+		aDigit, bDigit := 0, 0
+		if !aDone {
+			aDigit = int(aData.At(i))
+		}
+		if !bDone {
+			bDigit = int(bData.At(i))
+		}
+		if aDigit != bDigit {
+			return i, cmp.Compare(aDigit, bDigit), true
+		}
+	}
+	return maxDigits, 0, false
+}
+
+// EqualWithin returns true if a and b have the same exponent and agree on
+// their first sigDigits significant digits. A missing digit counts as 0.
+// EqualWithin panics if sigDigits is negative.
+func EqualWithin(a, b Number, sigDigits int) bool {
+	if sigDigits < 0 {
+		panic("sigDigits must be non-negative")
+	}
+	if a.IsZero() || b.IsZero() {
+		return a.IsZero() == b.IsZero()
+	}
+	if a.Exponent() != b.Exponent() {
+		return false
+	}
+	_, result, _ := firstDifference(
+		a.impl().mantissa, b.impl().mantissa, sigDigits)
+	return result == 0
+}
+
+// FirstDifference returns the position of the first significant digit
+// where a and b differ along with true. FirstDifference examines no more
+// than maxDigits significant digits of a and b, and a missing digit counts
+// as 0. If a and b have different exponents, FirstDifference returns 0,
+// true. If no difference is found within maxDigits digits or if a and b
+// are equal, FirstDifference returns -1, false. FirstDifference panics if
+// maxDigits is negative.
+func FirstDifference(a, b Number, maxDigits int) (position int, found bool) {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	if a.IsZero() && b.IsZero() {
+		return -1, false
+	}
+	if a.IsZero() || b.IsZero() || a.Exponent() != b.Exponent() {
+		if maxDigits == 0 {
+			return -1, false
+		}
+		return 0, true
+	}
+	posit, result, _ := firstDifference(
+		a.impl().mantissa, b.impl().mantissa, maxDigits)
+	if result == 0 {
+		return -1, false
+	}
+	return posit, true
+}
+
+// Min returns the smallest of ns. Min compares Numbers using Cmp with
+// maxDigits. If Min cannot determine the smallest of ns within maxDigits
+// digits, it returns ErrUndetermined. Min panics if ns is empty or if
+// maxDigits is negative.
+func Min(maxDigits int, ns ...Number) (Number, error) {
+	return extreme(maxDigits, -1, ns)
+}
+
+// Max returns the largest of ns. Max compares Numbers using Cmp with
+// maxDigits. If Max cannot determine the largest of ns within maxDigits
+// digits, it returns ErrUndetermined. Max panics if ns is empty or if
+// maxDigits is negative.
+func Max(maxDigits int, ns ...Number) (Number, error) {
+	return extreme(maxDigits, 1, ns)
+}
+
+// extreme returns the element of ns that compares as sign against every
+// other element of ns.
+func extreme(maxDigits, sign int, ns []Number) (Number, error) {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	if len(ns) == 0 {
+		panic("ns must be non-empty")
+	}
+	result := ns[0]
+	undetermined := false
+	for _, n := range ns[1:] {
+		c, ok := Cmp(n, result, maxDigits)
+		if !ok {
+
+			// n and result may be tied, but a later element may still
+			// beat both of them.
+			undetermined = true
+			continue
+		}
+		if c == sign {
+			result = n
+			undetermined = false
+		}
+	}
+	if undetermined {
+		return nil, ErrUndetermined
+	}
+	return result, nil
+}
+
+// CompareFinite returns -1 if a < b, 0 if a == b, or 1 if a > b. Unlike
+// Cmp, CompareFinite always gives an exact answer because a and b have a
+// finite number of digits. CompareFinite can be used with slices.SortFunc.
+func CompareFinite(a, b *FiniteNumber) int {
+	result, _ := Cmp(a, b, math.MaxInt)
+	return result
+}
+
+// SortFinite sorts ns in ascending order.
+func SortFinite(ns []*FiniteNumber) {
+	slices.SortFunc(ns, CompareFinite)
+}
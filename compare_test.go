@@ -0,0 +1,154 @@
+package sqrt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmp(t *testing.T) {
+	assertCmp(t, -1, Sqrt(2), Sqrt(3), 10)
+	assertCmp(t, 1, Sqrt(3), Sqrt(2), 10)
+	assertCmp(t, -1, Sqrt(2), Sqrt(200), 0)
+	assertCmp(t, 1, SqrtRat(1, 2), Sqrt(0), 0)
+	assertCmp(t, -1, Sqrt(0), SqrtRat(1, 2), 0)
+	assertCmp(t, 0, Sqrt(0), Sqrt(0), 0)
+	assertCmp(t, 0, Sqrt(100489), Sqrt(100489), 3)
+	assertCmp(t, -1, Sqrt(2).WithSignificant(20), Sqrt(2), 22)
+	assertCmp(t, 1, Sqrt(2), Sqrt(2).WithSignificant(20), 22)
+	assertCmp(t, 0, Sqrt(2).WithSignificant(20), Sqrt(2).WithSignificant(20), 20)
+}
+
+func TestCmpTrailingZeros(t *testing.T) {
+	a, _ := NewFiniteNumber([]int{1, 2}, 1)
+	b, _ := NewFiniteNumber([]int{1, 2, 0, 0}, 1)
+	assertCmp(t, 0, a, b, 4)
+	c, _ := NewNumberForTesting([]int{1, 2}, []int{0}, 1)
+	assertUndetermined(t, a, c, 100)
+}
+
+func TestCmpUndetermined(t *testing.T) {
+	assertUndetermined(t, Sqrt(2), Sqrt(2), 1000)
+	assertUndetermined(t, Sqrt(2), Sqrt(2).WithSignificant(20), 20)
+	assertUndetermined(t, Sqrt(2), Sqrt(3), 0)
+}
+
+func TestCmpPanics(t *testing.T) {
+	assert.Panics(t, func() { Cmp(Sqrt(2), Sqrt(3), -1) })
+}
+
+func assertCmp(t *testing.T, expected int, a, b Number, maxDigits int) {
+	t.Helper()
+	actual, ok := Cmp(a, b, maxDigits)
+	assert.True(t, ok)
+	assert.Equal(t, expected, actual)
+}
+
+func assertUndetermined(t *testing.T, a, b Number, maxDigits int) {
+	t.Helper()
+	actual, ok := Cmp(a, b, maxDigits)
+	assert.False(t, ok)
+	assert.Zero(t, actual)
+}
+
+func TestEqualWithin(t *testing.T) {
+	assert.True(t, EqualWithin(Sqrt(2), Sqrt(2), 1000))
+	assert.True(t, EqualWithin(Sqrt(2), Sqrt(2).WithSignificant(20), 21))
+	assert.False(t, EqualWithin(Sqrt(2), Sqrt(2).WithSignificant(20), 22))
+	assert.True(t, EqualWithin(Sqrt(2), Sqrt(3), 1))
+	assert.False(t, EqualWithin(Sqrt(2), Sqrt(3), 2))
+	assert.False(t, EqualWithin(Sqrt(2), Sqrt(200), 0))
+	assert.True(t, EqualWithin(Sqrt(0), Sqrt(0), 10))
+	assert.False(t, EqualWithin(Sqrt(0), Sqrt(2), 0))
+	assert.False(t, EqualWithin(Sqrt(2), Sqrt(0), 0))
+	assert.True(t, EqualWithin(Sqrt(2).WithSignificant(3), Sqrt(2), 3))
+	assert.Panics(t, func() { EqualWithin(Sqrt(2), Sqrt(2), -1) })
+}
+
+func TestFirstDifference(t *testing.T) {
+	assertFirstDifference(t, 1, Sqrt(2), Sqrt(3), 10)
+	assertFirstDifference(t, 21, Sqrt(2), Sqrt(2).WithSignificant(20), 22)
+	assertFirstDifference(t, 0, Sqrt(2), Sqrt(200), 10)
+	assertFirstDifference(t, 0, Sqrt(0), Sqrt(2), 10)
+	assertNoDifference(t, Sqrt(2), Sqrt(2), 1000)
+	assertNoDifference(t, Sqrt(2), Sqrt(3), 1)
+	assertNoDifference(t, Sqrt(2), Sqrt(200), 0)
+	assertNoDifference(t, Sqrt(0), Sqrt(0), 10)
+	assertNoDifference(t, Sqrt(2).WithSignificant(5), Sqrt(2).WithSignificant(5), 10)
+	assert.Panics(t, func() { FirstDifference(Sqrt(2), Sqrt(3), -1) })
+}
+
+func assertFirstDifference(
+	t *testing.T, expected int, a, b Number, maxDigits int) {
+	t.Helper()
+	actual, ok := FirstDifference(a, b, maxDigits)
+	assert.True(t, ok)
+	assert.Equal(t, expected, actual)
+}
+
+func assertNoDifference(t *testing.T, a, b Number, maxDigits int) {
+	t.Helper()
+	actual, ok := FirstDifference(a, b, maxDigits)
+	assert.False(t, ok)
+	assert.Equal(t, -1, actual)
+}
+
+func TestMinMax(t *testing.T) {
+	sqrt0, sqrt2, sqrt3, sqrt5 := Sqrt(0), Sqrt(2), Sqrt(3), Sqrt(5)
+	n, err := Min(10, sqrt3, sqrt2, sqrt5)
+	assert.NoError(t, err)
+	assert.Same(t, sqrt2, n)
+	n, err = Max(10, sqrt3, sqrt2, sqrt5)
+	assert.NoError(t, err)
+	assert.Same(t, sqrt5, n)
+	n, err = Min(0, Sqrt(200), sqrt0, sqrt2)
+	assert.NoError(t, err)
+	assert.Same(t, sqrt0, n)
+	n, err = Max(10, sqrt2)
+	assert.NoError(t, err)
+	assert.Same(t, sqrt2, n)
+}
+
+func TestMinMaxUndetermined(t *testing.T) {
+	_, err := Min(100, Sqrt(3), Sqrt(2), Sqrt(2))
+	assert.Equal(t, ErrUndetermined, err)
+	_, err = Max(100, Sqrt(2), Sqrt(2), Sqrt(3))
+	assert.NoError(t, err)
+	_, err = Max(1, Sqrt(2), Sqrt(3))
+	assert.Equal(t, ErrUndetermined, err)
+}
+
+func TestMinMaxPanics(t *testing.T) {
+	assert.Panics(t, func() { Min(10) })
+	assert.Panics(t, func() { Max(-1, Sqrt(2)) })
+}
+
+func TestCompareFinite(t *testing.T) {
+	a, _ := NewFiniteNumber([]int{1, 2}, 1)
+	b, _ := NewFiniteNumber([]int{1, 2, 0, 0}, 1)
+	c, _ := NewFiniteNumber([]int{1, 2, 0, 1}, 1)
+	assert.Equal(t, 0, CompareFinite(a, b))
+	assert.Equal(t, -1, CompareFinite(b, c))
+	assert.Equal(t, 1, CompareFinite(c, a))
+	assert.Equal(t, 0, CompareFinite(zeroNumber, zeroNumber))
+	assert.Equal(t, -1, CompareFinite(zeroNumber, a))
+}
+
+func TestSortFinite(t *testing.T) {
+	ns := []*FiniteNumber{
+		Sqrt(3).WithSignificant(10),
+		Sqrt(200).WithSignificant(10),
+		zeroNumber,
+		Sqrt(2).WithSignificant(10),
+		Sqrt(2).WithSignificant(5),
+	}
+	SortFinite(ns)
+	var actual []string
+	for _, n := range ns {
+		actual = append(actual, n.String())
+	}
+	assert.Equal(
+		t,
+		[]string{"0", "1.4142", "1.414213562", "1.732050807", "14.14213562"},
+		actual)
+}
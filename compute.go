@@ -1,12 +1,15 @@
 package sqrt
 
 import (
+	"bytes"
+	"math"
 	"math/big"
 )
 
 var (
 	one                  = big.NewInt(1)
 	two                  = big.NewInt(2)
+	five                 = big.NewInt(5)
 	six                  = big.NewInt(6)
 	ten                  = big.NewInt(10)
 	fortyFive            = big.NewInt(45)
@@ -14,6 +17,17 @@ var (
 	oneHundred           = big.NewInt(100)
 	oneHundredSeventyOne = big.NewInt(171)
 	oneThousand          = big.NewInt(1000)
+
+	// sqrtBlockBase is 10^kSqrtBlockDigits, and sqrtGroupBase is its
+	// square.
+	sqrtBlockBase = big.NewInt(1000000000)
+	sqrtGroupBase = big.NewInt(1000000000000000000)
+	maxSqrtBlock  = big.NewInt(999999999)
+)
+
+const (
+	kNewtonInitialDigits = 64
+	kSqrtBlockDigits     = 9
 )
 
 type rootManager interface {
@@ -24,30 +38,108 @@ type rootManager interface {
 
 func computeGroupsFromRational(num, denom, base *big.Int) (
 	groups func(result *big.Int) *big.Int, exp int) {
-	num = new(big.Int).Set(num)
-	denom = new(big.Int).Set(denom)
 	base = new(big.Int).Set(base)
-	for num.Cmp(denom) < 0 {
-		exp--
+	num, denom, exp = normalizeRational(num, denom, base)
+	groups = func(result *big.Int) *big.Int {
+		if num.Sign() == 0 {
+			return nil
+		}
 		num.Mul(num, base)
+		result.DivMod(num, denom, num)
+		return result
 	}
-	if exp < 0 {
-		exp++
-		num.Div(num, base)
+	return
+}
+
+// normalizeRational returns newNum, newDenom, and exp such that
+// newNum / newDenom = (num / denom) / base^exp and
+// 1 / base <= newNum / newDenom < 1. num and denom must be positive.
+// normalizeRational returns new big.Int instances and does not change num,
+// denom, or base.
+func normalizeRational(num, denom, base *big.Int) (
+	newNum, newDenom *big.Int, exp int) {
+	num = new(big.Int).Set(num)
+	denom = new(big.Int).Set(denom)
+
+	// Estimate exp up front so that radicans with a huge scale don't
+	// need one multiplication per group.
+	exp = int(math.Floor((log2(num) - log2(denom)) / log2(base)))
+	scale := new(big.Int).Exp(base, big.NewInt(int64(max(exp, -exp))), nil)
+	if exp > 0 {
+		denom.Mul(denom, scale)
+	} else {
+		num.Mul(num, scale)
 	}
+
+	// Fix up the estimate so that denom / base <= num < denom.
 	for num.Cmp(denom) >= 0 {
 		exp++
 		denom.Mul(denom, base)
 	}
-	groups = func(result *big.Int) *big.Int {
-		if num.Sign() == 0 {
-			return nil
+	var scaled big.Int
+	for scaled.Mul(num, base).Cmp(denom) < 0 {
+		exp--
+		num.Set(&scaled)
+	}
+	return num, denom, exp
+}
+
+// computeNewtonSqrtDigits returns the digits of the square root of
+// num / denom. 1/100 <= num / denom < 1 must hold so that the square root
+// is between 0.1 inclusive and 1.0 exclusive. Rather than computing one
+// digit at a time, computeNewtonSqrtDigits computes the integer square
+// root of num / denom * 10^(2k) with Newton's method, doubling k each time
+// it runs out of digits. It starts Newton's method from the previous
+// root, so each round needs only a couple of full precision divisions.
+// This makes the cost of computing d digits close to that of a few
+// multiplications of d digit numbers rather than proportional to d^2.
+func computeNewtonSqrtDigits(num, denom *big.Int) func() int {
+	num = new(big.Int).Set(num)
+	denom = new(big.Int).Set(denom)
+	var root, radican, rem, next, scale big.Int
+	var buffer []byte
+	produced := 0
+	done := false
+	return func() int {
+		if len(buffer) == 0 {
+			if done {
+				return -1
+			}
+			k := max(2*produced, kNewtonInitialDigits)
+			scale.Exp(ten, big.NewInt(int64(2*k)), nil)
+			radican.Mul(num, &scale)
+			radican.QuoRem(&radican, denom, &rem)
+			if produced == 0 {
+				root.Sqrt(&radican)
+			} else {
+
+				// (root + 1) * 10^(k - produced) is at least the new root,
+				// so Newton's method decreases monotonically from there.
+				scale.Exp(ten, big.NewInt(int64(k-produced)), nil)
+				root.Add(&root, one).Mul(&root, &scale)
+				for {
+					next.Quo(&radican, &root)
+					next.Add(&next, &root).Rsh(&next, 1)
+					if next.Cmp(&root) >= 0 {
+						break
+					}
+					root.Set(&next)
+				}
+			}
+			buffer = []byte(root.Text(10))[produced:]
+			produced = k
+			if rem.Sign() == 0 && next.Mul(&root, &root).Cmp(&radican) == 0 {
+				done = true
+				buffer = bytes.TrimRight(buffer, "0")
+				if len(buffer) == 0 {
+					return -1
+				}
+			}
 		}
-		num.Mul(num, base)
-		result.DivMod(num, denom, num)
+		result := int(buffer[0] - '0')
+		buffer = buffer[1:]
 		return result
 	}
-	return
 }
 
 func computeRootDigits(
@@ -77,6 +169,88 @@ func computeRootDigits(
 	}
 }
 
+// computeSqrtDigitsInBlocks returns the digits and exponent of the square
+// root of num / denom. num and denom must be positive. Instead of bringing
+// down one group of 2 digits at a time, computeSqrtDigitsInBlocks brings
+// down groups of 18 digits and finds kSqrtBlockDigits digits of the root
+// at once. If root is the root so far and remainder is what is left over,
+// the next block is the largest x below 10^9 such that
+// x * (2 * root * 10^9 + x) <= remainder. Since root has at least 10
+// digits after the first step, dividing remainder by 2 * root * 10^9
+// overestimates x by at most 1. This needs only a handful of big.Int
+// operations per block instead of per digit.
+func computeSqrtDigitsInBlocks(num, denom *big.Int) (
+	digits func() int, exp int) {
+	groups, groupExp := computeGroupsFromRational(num, denom, sqrtGroupBase)
+	var root, remainder, group, pending, divisor, block, temp big.Int
+
+	// Reading one group ahead tells us when the current group is the last.
+	exhausted := groups(&pending) == nil
+	nextGroup := func() {
+		group.Set(&pending)
+		if !exhausted && groups(&pending) == nil {
+			exhausted = true
+			pending.SetInt64(0)
+		}
+	}
+	done := false
+	var buffer []byte
+	finishBlock := func() {
+		if exhausted && remainder.Sign() == 0 {
+			buffer = bytes.TrimRight(buffer, "0")
+			done = true
+		}
+	}
+
+	// Start with two groups so that root has at least 10 digits.
+	nextGroup()
+	remainder.Set(&group)
+	nextGroup()
+	remainder.Mul(&remainder, sqrtGroupBase).Add(&remainder, &group)
+	root.Sqrt(&remainder)
+	remainder.Sub(&remainder, temp.Mul(&root, &root))
+	buffer = zeroPaddedDigits(&root, 2*kSqrtBlockDigits)
+	leadingZeros := len(buffer) - len(bytes.TrimLeft(buffer, "0"))
+	buffer = buffer[leadingZeros:]
+	finishBlock()
+
+	digits = func() int {
+		for len(buffer) == 0 {
+			if done {
+				return -1
+			}
+			nextGroup()
+			remainder.Mul(&remainder, sqrtGroupBase).Add(&remainder, &group)
+			divisor.Mul(&root, sqrtBlockBase).Lsh(&divisor, 1)
+			block.Quo(&remainder, &divisor)
+			if block.Cmp(maxSqrtBlock) > 0 {
+				block.Set(maxSqrtBlock)
+			}
+			for temp.Add(&divisor, &block).Mul(&temp, &block).Cmp(&remainder) > 0 {
+				block.Sub(&block, one)
+			}
+			remainder.Sub(&remainder, &temp)
+			root.Mul(&root, sqrtBlockBase).Add(&root, &block)
+			buffer = zeroPaddedDigits(&block, kSqrtBlockDigits)
+			finishBlock()
+		}
+		result := int(buffer[0] - '0')
+		buffer = buffer[1:]
+		return result
+	}
+	return digits, kSqrtBlockDigits*groupExp - leadingZeros
+}
+
+// zeroPaddedDigits returns the base 10 digits of x padded with leading
+// zeros to width digits. x must be non-negative.
+func zeroPaddedDigits(x *big.Int, width int) []byte {
+	digits := x.Append(nil, 10)
+	if len(digits) >= width {
+		return digits
+	}
+	return append(bytes.Repeat([]byte{'0'}, width-len(digits)), digits...)
+}
+
 type sqrtManager struct {
 }
 
@@ -123,3 +297,56 @@ func (c *cubeRootManager) NextDigit(incr *big.Int) {
 func (c *cubeRootManager) Base(result *big.Int) *big.Int {
 	return result.Set(oneThousand)
 }
+
+// rootManagerFactory returns the function that creates rootManagers for
+// computing nth roots. n must be positive.
+func rootManagerFactory(n int) func() rootManager {
+	switch n {
+	case 2:
+		return newSqrtManager
+	case 3:
+		return newCubeRootManager
+	default:
+		return func() rootManager {
+			return &nRootManager{n: big.NewInt(int64(n))}
+		}
+	}
+}
+
+// nRootManager computes nth roots for any positive n. It is slower than
+// the specialized managers for square and cube roots.
+type nRootManager struct {
+	n    *big.Int
+	root big.Int
+}
+
+func (m *nRootManager) Next(incr *big.Int) {
+	m.root.Add(&m.root, one)
+	m.setIncr(incr)
+}
+
+func (m *nRootManager) NextDigit(incr *big.Int) {
+	m.root.Mul(&m.root, ten)
+	m.setIncr(incr)
+}
+
+func (m *nRootManager) Base(result *big.Int) *big.Int {
+	return result.Exp(ten, m.n, nil)
+}
+
+// setIncr sets incr to (root+1)^n - root^n.
+func (m *nRootManager) setIncr(incr *big.Int) {
+	var temp big.Int
+	temp.Add(&m.root, one)
+	incr.Exp(&temp, m.n, nil)
+	incr.Sub(incr, temp.Exp(&m.root, m.n, nil))
+}
+
+// log2 returns an approximation of the base 2 logarithm of x. x must be
+// positive.
+func log2(x *big.Int) float64 {
+	var mant big.Float
+	exp := new(big.Float).SetInt(x).MantExp(&mant)
+	m, _ := mant.Float64()
+	return float64(exp) + math.Log2(m)
+}
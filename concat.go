@@ -0,0 +1,107 @@
+package sqrt
+
+import "slices"
+
+// kConcatBatchSize is how many digits at a time Concat reads from each
+// FiniteSequence.
+const kConcatBatchSize = 1024
+
+// Concat returns a FiniteSequence with the digits of each FiniteSequence
+// in seqs one after another. The positions in the returned FiniteSequence
+// start at 0 and have no gaps regardless of the positions in seqs. Concat
+// reads digits from seqs only as needed.
+func Concat(seqs ...FiniteSequence) FiniteSequence {
+	asSeqs := make([]Sequence, len(seqs))
+	for i, s := range seqs {
+		asSeqs[i] = s
+	}
+	return &finiteSequence{
+		sequencePart{mantissa: newmantissa(concatDigits(asSeqs))}}
+}
+
+// concatDigits returns a function that returns the digits of each
+// Sequence in seqs one after another followed by -1. concatDigits reads
+// the digits of each Sequence in batches of kConcatBatchSize.
+func concatDigits(seqs []Sequence) func() int {
+	var current Sequence
+	if len(seqs) > 0 {
+		current = seqs[0]
+	}
+	var buffer []int
+	return func() int {
+		for len(buffer) == 0 {
+			if current == nil {
+				return -1
+			}
+			last := -1
+			for posit, digit := range current.All() {
+				buffer = append(buffer, digit)
+				last = posit
+				if len(buffer) == kConcatBatchSize {
+					break
+				}
+			}
+			if last != -1 {
+				current = current.WithStart(last + 1)
+				continue
+			}
+			seqs = seqs[1:]
+			current = nil
+			if len(seqs) > 0 {
+				current = seqs[0]
+			}
+		}
+		result := buffer[0]
+		buffer = buffer[1:]
+		return result
+	}
+}
+
+// Repeat returns a FiniteSequence with the digits of s repeated n times.
+// Like Concat, the positions in the returned FiniteSequence start at 0.
+// Repeat panics if n is negative.
+func Repeat(s FiniteSequence, n int) FiniteSequence {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	return Concat(slices.Repeat([]FiniteSequence{s}, n)...)
+}
+
+// RepeatForever returns a Sequence with the digits of s repeated forever.
+// The positions in the returned Sequence start at 0. If s is empty, so is
+// the returned Sequence.
+func RepeatForever(s FiniteSequence) Sequence {
+	var digits []int
+	index := -1
+	return &sequence{sequencePart{mantissa: newmantissa(func() int {
+		if index == -1 {
+			digits = slices.Collect(s.Values())
+			index = 0
+		}
+		if len(digits) == 0 {
+			return -1
+		}
+		result := digits[index]
+		index = (index + 1) % len(digits)
+		return result
+	})}}
+}
+
+func reversed(s FiniteSequence) FiniteSequence {
+	var digits []int
+	index := -1
+	return &finiteSequence{sequencePart{mantissa: newmantissa(func() int {
+		if index == -1 {
+			for _, digit := range s.Backward() {
+				digits = append(digits, digit)
+			}
+			index = 0
+		}
+		if index == len(digits) {
+			return -1
+		}
+		result := digits[index]
+		index++
+		return result
+	})}}
+}
@@ -0,0 +1,85 @@
+package sqrt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcat(t *testing.T) {
+	n := Sqrt(2)
+	s := Concat(
+		n.WithEnd(3), n.WithStart(10).WithEnd(13), Sqrt(256).WithEnd(5))
+	assert.Equal(t, "14137316", AsString(s))
+	assert.Equal(t, 8, s.Len())
+	assert.Equal(t, 7, s.At(4))
+	var positions []int
+	for posit := range s.All() {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, positions)
+	assert.Equal(t, "316", AsString(s.FiniteWithStart(5)))
+}
+
+func TestConcatLarge(t *testing.T) {
+	n := Sqrt(2)
+	s := Concat(n.WithEnd(2500), n.WithStart(2500).WithEnd(5000))
+	assert.Equal(t, AsString(n.WithEnd(5000)), AsString(s))
+}
+
+func TestConcatStrided(t *testing.T) {
+	n := Sqrt(2)
+	strided := n.WithEnd(10).Stride(3).(FiniteSequence)
+	s := Concat(strided, zeroNumber, n.WithEnd(2))
+	assert.Equal(t, "143214", AsString(s))
+}
+
+func TestConcatEmpty(t *testing.T) {
+	assert.Zero(t, Concat().Len())
+	assert.Zero(t, Concat(zeroNumber, Sqrt(2).WithEnd(0)).Len())
+}
+
+func TestRepeat(t *testing.T) {
+	s := Repeat(Sqrt(2).WithStart(1).WithEnd(4), 3)
+	assert.Equal(t, "414414414", AsString(s))
+	assert.Equal(t, 9, s.Len())
+	assert.Zero(t, Repeat(Sqrt(2).WithEnd(4), 0).Len())
+	assert.Panics(t, func() { Repeat(Sqrt(2).WithEnd(4), -1) })
+}
+
+func TestRepeatForever(t *testing.T) {
+	s := RepeatForever(Sqrt(2).WithStart(1).WithEnd(4))
+	assert.Equal(t, "41441441", AsString(s.WithEnd(8)))
+	assert.Equal(t, "1441", AsString(s.WithStart(1000).WithEnd(1004)))
+	assert.Zero(t, RepeatForever(zeroNumber).WithEnd(100).Len())
+}
+
+func TestReversed(t *testing.T) {
+	n := Sqrt(2)
+	s := n.WithStart(3).WithEnd(10).Reversed()
+	assert.Equal(t, "2653124", AsString(s))
+	assert.Equal(t, "531", AsString(s.FiniteWithStart(2).WithEnd(5)))
+	assert.Equal(t, 2, s.At(0))
+	assert.Equal(t, "4213562", AsString(s.Reversed()))
+	assert.Equal(t, "61", AsString(Sqrt(256).WithSignificant(5).Reversed()))
+	strided := n.WithEnd(10).Stride(3).(FiniteSequence)
+	assert.Equal(t, "2341", AsString(strided.Reversed()))
+	assert.Zero(t, zeroNumber.Reversed().Len())
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	n := NewNumber(SequenceGenerator(Sqrt(2).WithStart(3).WithEnd(7), 2))
+	assert.Equal(t, "42.13", n.String())
+	n = NewNumber(SequenceGenerator(Sqrt(2).WithStart(1), 0))
+	assert.Equal(t, 3, n.At(5))
+	assert.Equal(t, 0, n.Exponent())
+	n = NewNumber(SequenceGenerator(
+		MapSeq(Sqrt(2).WithEnd(3), func(index, digit int) int {
+			return 9 - digit
+		}), -1))
+	assert.Equal(t, "0.0858", n.String())
+	assert.True(t, NewNumber(SequenceGenerator(Sqrt(2).WithEnd(0), 5)).IsZero())
+
+	// Leading zero means zero
+	assert.True(t, NewNumber(SequenceGenerator(Sqrt(101).WithStart(1), 1)).IsZero())
+}
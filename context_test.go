@@ -100,6 +100,21 @@ func TestNumComputed(t *testing.T) {
 	assert.Greater(t, oneMillis.NumComputed(), 0)
 }
 
+func TestKnownDigits(t *testing.T) {
+	n := Sqrt(7)
+	assert.Zero(t, n.KnownDigits())
+	n.At(99)
+	known := n.KnownDigits()
+	assert.GreaterOrEqual(t, known, 100)
+	assert.Equal(t, known, n.KnownDigits())
+	assert.Equal(t, known, n.Snapshot().Len())
+	finite := Sqrt(2).WithSignificant(5)
+	finite.At(10)
+	assert.Equal(t, 5, finite.KnownDigits())
+	var zero FiniteNumber
+	assert.Zero(t, zero.KnownDigits())
+}
+
 func TestNumComputedFinite(t *testing.T) {
 	n := Sqrt(100489)
 	assert.Equal(t, 0, n.NumComputed())
@@ -231,3 +246,18 @@ func computeFor(n Number, duration time.Duration) {
 	defer cancel()
 	n.WithSignificant(math.MaxInt).PrimeToEnd(ctx)
 }
+
+func TestSnapshot(t *testing.T) {
+	n := Sqrt(7)
+	assert.True(t, n.Snapshot().IsZero())
+	n.At(99)
+	computed := n.NumComputed()
+	snapshot := n.Snapshot()
+	assert.Equal(t, computed, n.NumComputed())
+	assert.Equal(t, n.Exponent(), snapshot.Exponent())
+	assert.Equal(t, AsString(n.WithEnd(computed)), AsString(snapshot))
+	assert.Equal(t, computed, snapshot.Len())
+	finite := Sqrt(2).WithSignificant(5)
+	finite.At(10)
+	assert.Equal(t, "1.4142", finite.Snapshot().Exact())
+}
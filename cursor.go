@@ -0,0 +1,79 @@
+package sqrt
+
+import (
+	"iter"
+	"math"
+)
+
+// Cursor reads the digits of a Number one at a time starting at any
+// position. Unlike ranging over All, a Cursor can stop and resume reading
+// without creating new views. A Cursor is not safe to use from multiple
+// goroutines, but the Number it reads from is.
+type Cursor struct {
+	mantissa mantissa
+	pos      int
+}
+
+// Seek moves this Cursor so that Next returns the digit at the 0 based
+// position pos. Seek panics if pos is negative.
+func (c *Cursor) Seek(pos int) {
+	if pos < 0 {
+		panic("pos must be non-negative")
+	}
+	c.pos = pos
+}
+
+// Next returns the digit at Pos() along with true and advances this
+// Cursor by one. If there is no digit at Pos(), Next returns 0, false and
+// does not advance.
+func (c *Cursor) Next() (digit int, ok bool) {
+	digit = c.mantissa.At(c.pos)
+	if digit == -1 {
+		return 0, false
+	}
+	c.pos++
+	return digit, true
+}
+
+// Pos returns the 0 based position of the digit that Next returns next.
+func (c *Cursor) Pos() int {
+	return c.pos
+}
+
+func (n *numberPart) Cursor() *Cursor {
+	return &Cursor{mantissa: n.mantissa}
+}
+
+// ResumeToken marks where to resume iterating over a Sequence. The zero
+// ResumeToken means the beginning. ResumeTokens count positions from the
+// Start of the Sequence, so they work even for views with negative
+// positions. ResumeTokens are plain integers, so they can be saved and
+// passed between processes.
+type ResumeToken int
+
+// allFrom returns the digits that allInRange returns from the position
+// that token marks onward. start is the Start of the Sequence.
+func allFrom(
+	allInRange func(start, end int) iter.Seq2[int, int],
+	start int,
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	if token < 0 {
+		panic("token must be non-negative")
+	}
+	from := math.MaxInt
+	if start <= 0 || int(token) <= math.MaxInt-start {
+		from = start + int(token)
+	}
+	return resumable(allInRange(from, math.MaxInt), start)
+}
+
+func resumable(
+	digits iter.Seq2[int, int], start int) iter.Seq2[ResumeToken, int] {
+	return func(yield func(token ResumeToken, digit int) bool) {
+		for index, digit := range digits {
+			if !yield(ResumeToken(index-start+1), digit) {
+				return
+			}
+		}
+	}
+}
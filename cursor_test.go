@@ -0,0 +1,108 @@
+package sqrt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	c := Sqrt(2).Cursor()
+	assert.Equal(t, 0, c.Pos())
+	assertNext(t, c, 1)
+	assertNext(t, c, 4)
+	assert.Equal(t, 2, c.Pos())
+	c.Seek(1000)
+	assertNext(t, c, Sqrt(2).At(1000))
+	assert.Equal(t, 1001, c.Pos())
+	c.Seek(3)
+	assertNext(t, c, 4)
+	assertNext(t, c, 2)
+	assert.Panics(t, func() { c.Seek(-1) })
+}
+
+func TestCursorFinite(t *testing.T) {
+	c := Sqrt(256).Cursor()
+	assertNext(t, c, 1)
+	assertNext(t, c, 6)
+	_, ok := c.Next()
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.Pos())
+	c = Sqrt(2).WithSignificant(1).Cursor()
+	assertNext(t, c, 1)
+	_, ok = c.Next()
+	assert.False(t, ok)
+	_, ok = zeroNumber.Cursor().Next()
+	assert.False(t, ok)
+}
+
+func assertNext(t *testing.T, c *Cursor, expected int) {
+	t.Helper()
+	digit, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, expected, digit)
+}
+
+func TestAllFrom(t *testing.T) {
+	n := Sqrt(2)
+	var token ResumeToken
+	var digits []int
+	for batch := 0; batch < 3; batch++ {
+		count := 0
+		for next, digit := range n.AllFrom(token) {
+			digits = append(digits, digit)
+			token = next
+			count++
+			if count == 4 {
+				break
+			}
+		}
+	}
+	assert.Equal(t, ResumeToken(12), token)
+	assert.Equal(t, []int{1, 4, 1, 4, 2, 1, 3, 5, 6, 2, 3, 7}, digits)
+}
+
+func TestAllFromViews(t *testing.T) {
+	s := Sqrt(2).WithStart(3).WithEnd(8)
+	var tokens []ResumeToken
+	for token := range s.AllFrom(0) {
+		tokens = append(tokens, token)
+	}
+	assert.Equal(t, []ResumeToken{1, 2, 3, 4, 5}, tokens)
+	var digits []int
+	for _, digit := range s.AllFrom(3) {
+		digits = append(digits, digit)
+	}
+	assert.Equal(t, []int{3, 5}, digits)
+	tokens = nil
+	for token, digit := range Sqrt(2).Stride(3).WithEnd(10).AllFrom(4) {
+		tokens = append(tokens, token)
+		assert.Equal(t, Sqrt(2).At(int(token)-1), digit)
+	}
+	assert.Equal(t, []ResumeToken{7, 10}, tokens)
+	for range Sqrt(256).AllFrom(2) {
+		assert.Fail(t, "expected no digits")
+	}
+	assert.Panics(t, func() { Sqrt(2).AllFrom(-1) })
+}
+
+func TestBackwardFrom(t *testing.T) {
+	var positions, digits []int
+	for posit, digit := range Sqrt(2).BackwardFrom(5) {
+		positions = append(positions, posit)
+		digits = append(digits, digit)
+	}
+	assert.Equal(t, []int{4, 3, 2, 1, 0}, positions)
+	assert.Equal(t, []int{2, 4, 1, 4, 1}, digits)
+	positions = nil
+	for posit := range Sqrt(256).BackwardFrom(100) {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{1, 0}, positions)
+	for range Sqrt(2).BackwardFrom(0) {
+		assert.Fail(t, "expected no digits")
+	}
+	for range Sqrt(2).BackwardFrom(-1) {
+		assert.Fail(t, "expected no digits")
+	}
+}
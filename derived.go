@@ -0,0 +1,307 @@
+package sqrt
+
+import (
+	"context"
+	"io"
+	"iter"
+	"math"
+)
+
+// MapSeq returns a view of s with each digit d at position p replaced by
+// f(p, d). The digits in the returned view keep their positions. f must
+// return a digit between 0 and 9. If s is a FiniteSequence, so is the
+// returned view. MapSeq calls f only as digits are needed.
+func MapSeq(s Sequence, f func(index, digit int) int) Sequence {
+	return newDerived(s, func(start, end int) iter.Seq2[int, int] {
+		return func(yield func(index, value int) bool) {
+			for index, digit := range s.AllInRange(start, end) {
+				if !yield(index, f(index, digit)) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// FilterSeq returns a view of s with only the digits d at positions p for
+// which f(p, d) returns true. The digits in the returned view keep their
+// positions, so the view has gaps wherever f returns false. If s is a
+// FiniteSequence, so is the returned view. Its Len is the number of digits
+// that pass f, while its Start and End are the same as for s, so End
+// bounds the positions of the digits rather than following the last one.
+// Iterating over the returned view never ends if s is infinite and f stops
+// returning true. Likewise, IsEmpty on the returned view scans s until f
+// returns true.
+func FilterSeq(s Sequence, f func(index, digit int) bool) Sequence {
+	return newDerived(s, func(start, end int) iter.Seq2[int, int] {
+		return func(yield func(index, value int) bool) {
+			for index, digit := range s.AllInRange(start, end) {
+				if f(index, digit) && !yield(index, digit) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// byDecimalPlace returns a view of the digits of n indexed by decimal
+// place.
+func byDecimalPlace(n Number) Sequence {
+	offset := 1 - n.Exponent()
+	part := derivedPart{
+		base:   n,
+		offset: offset,
+		allInRange: func(start, end int) iter.Seq2[int, int] {
+			return func(yield func(index, value int) bool) {
+				baseStart := unshiftPosition(max(start, offset), offset)
+				baseEnd := unshiftPosition(end, offset)
+				for posit, digit := range n.AllInRange(baseStart, baseEnd) {
+					if !yield(posit+offset, digit) {
+						return
+					}
+				}
+			}
+		},
+		start: math.MinInt,
+		end:   math.MaxInt,
+	}
+	if _, ok := n.(FiniteSequence); ok {
+		return &finiteDerivedSequence{part}
+	}
+	return &derivedSequence{part}
+}
+
+// unshiftPosition returns posit - offset clamped to math.MaxInt.
+func unshiftPosition(posit, offset int) int {
+	if offset < 0 && posit > math.MaxInt+offset {
+		return math.MaxInt
+	}
+	return posit - offset
+}
+
+func newDerived(
+	s Sequence, allInRange func(start, end int) iter.Seq2[int, int]) Sequence {
+	part := derivedPart{base: s, allInRange: allInRange, end: math.MaxInt}
+	if _, ok := s.(FiniteSequence); ok {
+		return &finiteDerivedSequence{part}
+	}
+	return &derivedSequence{part}
+}
+
+// derivedPart is a view of the digits that allInRange returns for
+// positions at least start and less than end. base is the Sequence that
+// the digits come from. offset is what to add to a position in base to
+// get the position in this view.
+type derivedPart struct {
+	base       Sequence
+	allInRange func(start, end int) iter.Seq2[int, int]
+	start      int
+	end        int
+	offset     int
+}
+
+func (d *derivedPart) All() iter.Seq2[int, int] {
+	return d.allInRange(d.start, d.end)
+}
+
+func (d *derivedPart) AllInRange(start, end int) iter.Seq2[int, int] {
+	return d.allInRange(max(start, d.start), min(end, d.end))
+}
+
+func (d *derivedPart) AllFrom(
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	return allFrom(d.AllInRange, d.Start(), token)
+}
+
+func (d *derivedPart) Values() iter.Seq[int] {
+	return func(yield func(value int) bool) {
+		for _, digit := range d.All() {
+			if !yield(digit) {
+				return
+			}
+		}
+	}
+}
+
+func (d *derivedPart) Chunks(size int) iter.Seq2[int, []int8] {
+	return chunksOf(d.All(), size)
+}
+
+func (d *derivedPart) ValuesBase(pow int) iter.Seq[int] {
+	return valuesBase(d.Values(), pow)
+}
+
+func (d *derivedPart) PackedDigits(max int) []byte {
+	return appendPackedDigits(nil, d.Values(), max)
+}
+
+// String returns the first 16 digits of this view followed by "..." if
+// there are more digits.
+func (d *derivedPart) String() string {
+	return valuesString(d.Values())
+}
+
+func (d *derivedPart) PrimeToStart(ctx context.Context) error {
+	return d.base.PrimeToStart(ctx)
+}
+
+func (d *derivedPart) Start() int {
+	return max(d.start, d.base.Start()+d.offset)
+}
+
+func (d *derivedPart) withStart(start int) derivedPart {
+	result := *d
+	result.start = max(start, d.start)
+	return result
+}
+
+func (d *derivedPart) withEnd(end int) derivedPart {
+	result := *d
+	result.end = min(end, d.end)
+	return result
+}
+
+// withStride returns every kth digit of this view.
+func (d *derivedPart) withStride(k int) derivedPart {
+	if k <= 0 {
+		panic("k must be positive")
+	}
+	all := d.All()
+	return derivedPart{
+		base: d.base,
+		allInRange: func(start, end int) iter.Seq2[int, int] {
+			return func(yield func(index, value int) bool) {
+				count := 0
+				for index, digit := range all {
+					if index >= end {
+						return
+					}
+					if count%k == 0 && index >= start && !yield(index, digit) {
+						return
+					}
+					count++
+				}
+			}
+		},
+		start:  d.start,
+		end:    math.MaxInt,
+		offset: d.offset,
+	}
+}
+
+type derivedSequence struct {
+	derivedPart
+}
+
+func (d *derivedSequence) WithStart(start int) Sequence {
+	return &derivedSequence{d.withStart(start)}
+}
+
+func (d *derivedSequence) WithEnd(end int) FiniteSequence {
+	return &finiteDerivedSequence{d.withEnd(end)}
+}
+
+func (d *derivedSequence) Stride(k int) Sequence {
+	return &derivedSequence{d.withStride(k)}
+}
+
+func (d *derivedSequence) private() {
+}
+
+type finiteDerivedSequence struct {
+	derivedPart
+}
+
+func (f *finiteDerivedSequence) WithStart(start int) Sequence {
+	return f.FiniteWithStart(start)
+}
+
+func (f *finiteDerivedSequence) FiniteWithStart(start int) FiniteSequence {
+	return &finiteDerivedSequence{f.withStart(start)}
+}
+
+func (f *finiteDerivedSequence) WithEnd(end int) FiniteSequence {
+	return &finiteDerivedSequence{f.withEnd(end)}
+}
+
+func (f *finiteDerivedSequence) Stride(k int) Sequence {
+	return &finiteDerivedSequence{f.withStride(k)}
+}
+
+func (f *finiteDerivedSequence) Backward() iter.Seq2[int, int] {
+	return func(yield func(index, value int) bool) {
+		var indexes, digits []int
+		for index, digit := range f.All() {
+			indexes = append(indexes, index)
+			digits = append(digits, digit)
+		}
+		for i := len(indexes) - 1; i >= 0; i-- {
+			if !yield(indexes[i], digits[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (f *finiteDerivedSequence) IsEmpty() bool {
+	for range f.All() {
+		return false
+	}
+	return true
+}
+
+func (f *finiteDerivedSequence) End() (int, bool) {
+	end, ok := f.end, f.end != math.MaxInt
+	if base, isFinite := f.base.(FiniteSequence); isFinite {
+		if baseEnd, baseOk := base.End(); baseOk {
+			baseEnd += f.offset
+			if !ok || baseEnd < end {
+				return baseEnd, true
+			}
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return end, true
+}
+
+func (f *finiteDerivedSequence) AllInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return f.FiniteWithStart(start).WithEnd(end).Backward()
+}
+
+func (f *finiteDerivedSequence) PrimeToEnd(ctx context.Context) error {
+	for range f.All() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *finiteDerivedSequence) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, f)
+}
+
+func (f *finiteDerivedSequence) Len() int {
+	count := 0
+	for range f.All() {
+		count++
+	}
+	return count
+}
+
+func (f *finiteDerivedSequence) Reversed() FiniteSequence {
+	return reversed(f)
+}
+
+func (f *finiteDerivedSequence) At(posit int) int {
+	for _, digit := range f.AllInRange(posit, posit+1) {
+		return digit
+	}
+	return -1
+}
+
+func (f *finiteDerivedSequence) private() {
+}
@@ -0,0 +1,157 @@
+package sqrt
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func complement(index, digit int) int {
+	return 9 - digit
+}
+
+func even(index, digit int) bool {
+	return digit%2 == 0
+}
+
+func TestMapSeq(t *testing.T) {
+	s := MapSeq(Sqrt(2), complement)
+	assert.Equal(t, "8585786437", AsString(s.WithEnd(10)))
+	assert.Equal(t, "8643", AsString(s.WithStart(5).WithEnd(9)))
+	var positions []int
+	for posit := range s.WithStart(3).WithEnd(6).All() {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{3, 4, 5}, positions)
+	assert.Equal(t, "8567", AsString(s.Stride(3).WithEnd(10)))
+	assert.NoError(t, s.PrimeToStart(context.Background()))
+}
+
+func TestMapSeqUsesIndex(t *testing.T) {
+	s := MapSeq(Sqrt(2).WithEnd(6), func(index, digit int) int {
+		if index >= 3 {
+			return 0
+		}
+		return digit
+	})
+	assert.Equal(t, "141000", AsString(s.(FiniteSequence)))
+}
+
+func TestMapSeqFinite(t *testing.T) {
+	s, ok := MapSeq(Sqrt(2).WithSignificant(6), complement).(FiniteSequence)
+	assert.True(t, ok)
+	assert.Equal(t, "858578", AsString(s))
+	assert.Equal(t, 6, s.Len())
+	assert.Equal(t, 7, s.At(4))
+	assert.Equal(t, -1, s.At(6))
+	assert.Equal(t, "875858", AsString(s.Reversed()))
+	var positions []int
+	for posit := range s.Backward() {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{5, 4, 3, 2, 1, 0}, positions)
+	assert.Equal(t, "578", AsString(s.FiniteWithStart(3)))
+	assert.NoError(t, s.PrimeToEnd(context.Background()))
+}
+
+func TestFilterSeq(t *testing.T) {
+	s := FilterSeq(Sqrt(2), even)
+	var positions, digits []int
+	for posit, digit := range s.WithEnd(12).All() {
+		positions = append(positions, posit)
+		digits = append(digits, digit)
+	}
+	assert.Equal(t, []int{1, 3, 4, 8, 9}, positions)
+	assert.Equal(t, []int{4, 4, 2, 6, 2}, digits)
+	fs := s.WithEnd(12)
+	assert.Equal(t, 5, fs.Len())
+	assert.Equal(t, 6, fs.At(8))
+	assert.Equal(t, -1, fs.At(7))
+	assert.Equal(t, "422", AsString(fs.Stride(2).(FiniteSequence)))
+	assert.Equal(t, []int{2, 6, 2}, slices.Collect(fs.FiniteWithStart(4).Values()))
+
+	// Start and End bound the positions. Len counts the digits.
+	assert.Equal(t, 0, fs.Start())
+	end, ok := fs.End()
+	assert.True(t, ok)
+	assert.Equal(t, 12, end)
+}
+
+func TestFilterSeqChunks(t *testing.T) {
+	s := FilterSeq(Sqrt(2).WithEnd(12), even).(FiniteSequence)
+	var indexes []int
+	var chunks [][]int8
+	for index, chunk := range s.Chunks(2) {
+		indexes = append(indexes, index)
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, []int{1, 4, 9}, indexes)
+	assert.Equal(t, [][]int8{{4, 4}, {2, 6}, {2}}, chunks)
+	assert.Equal(t, []int{44, 26, 20}, slices.Collect(s.ValuesBase(2)))
+	assert.Equal(t, []byte{0x44, 0x26, 0x2f}, s.PackedDigits(10))
+}
+
+func TestByDecimalPlace(t *testing.T) {
+	// 1.414213562
+	s := Sqrt(2).ByDecimalPlace()
+	assert.Equal(t, 0, s.Start())
+	assert.Equal(t, []int{4, 1, 4}, collect(s.AllInRange(1, 4), 0))
+	var indexes []int
+	for index := range s.WithEnd(3).All() {
+		indexes = append(indexes, index)
+	}
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	_, ok := s.(FiniteSequence)
+	assert.False(t, ok)
+
+	// 123.45
+	n := MustFinite([]int{1, 2, 3, 4, 5}, 3)
+	fs := n.ByDecimalPlace().(FiniteSequence)
+	assert.Equal(t, -2, fs.Start())
+	_, ok = fs.End()
+	assert.False(t, ok)
+	end, ok := n.WithSignificant(4).ByDecimalPlace().(FiniteSequence).End()
+	assert.True(t, ok)
+	assert.Equal(t, 2, end)
+	assert.Equal(t, 3, fs.At(0))
+	assert.Equal(t, 1, fs.At(-2))
+	assert.Equal(t, 5, fs.At(2))
+	assert.Equal(t, -1, fs.At(3))
+	assert.Equal(t, "45", AsString(fs.FiniteWithStart(1)))
+	assert.Equal(t, "123", AsString(fs.WithEnd(1)))
+
+	// 0.005
+	small := SqrtRat(25, 1000000).ByDecimalPlace().(FiniteSequence)
+	assert.Equal(t, 3, small.Start())
+	assert.Equal(t, 5, small.At(3))
+	assert.Equal(t, -1, small.At(1))
+	assert.Equal(t, "5", AsString(small.FiniteWithStart(2)))
+}
+
+func TestByDecimalPlaceNegativePositions(t *testing.T) {
+	// 123.45
+	s := MustFinite([]int{1, 2, 3, 4, 5}, 3).ByDecimalPlace()
+	assert.Equal(t, "12", AsString(Take(s, 2)))
+	assert.Equal(t, "", AsString(Take(s, 0)))
+	assert.Equal(t, "12345", AsString(Take(s, math.MaxInt)))
+	dropped, ok := AsFiniteSequence(Drop(s, 3))
+	assert.True(t, ok)
+	assert.Equal(t, 1, dropped.Start())
+	assert.Equal(t, "45", AsString(dropped))
+	var tokens []ResumeToken
+	var digits []int
+	for token, digit := range s.AllFrom(0) {
+		tokens = append(tokens, token)
+		digits = append(digits, digit)
+	}
+	assert.Equal(t, []ResumeToken{1, 2, 3, 4, 5}, tokens)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, digits)
+	digits = nil
+	for _, digit := range s.AllFrom(2) {
+		digits = append(digits, digit)
+	}
+	assert.Equal(t, []int{3, 4, 5}, digits)
+}
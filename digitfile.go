@@ -0,0 +1,218 @@
+package sqrt
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	kDigitFileHeader   = "# sqrt digit file"
+	kDigitFilePerGroup = 10
+	kDigitFilePerLine  = 50
+)
+
+// Metadata describes the digits in a digit file.
+type Metadata struct {
+
+	// Operation is how the digits were computed, for example "sqrt". Empty
+	// means unknown.
+	Operation string
+
+	// Radicand is the value that Operation was applied to, for example
+	// "2". Empty means unknown.
+	Radicand string
+
+	// Exponent is the exponent of the number that the digits belong to.
+	Exponent int
+
+	// Count is the number of digits.
+	Count int
+
+	// Checksum is the hex encoded SHA-256 hash of the digits written as
+	// ASCII characters '0' through '9'.
+	Checksum string
+}
+
+// WriteDigitFile writes the first count significant digits of n to w as a
+// digit file. A digit file has a header giving the operation, radicand,
+// exponent, digit count, and checksum followed by the digits in the
+// layout of FormatBlocks. WriteDigitFile takes Operation and Radicand from
+// meta and computes the other header fields itself. ReadDigitFile reads
+// what WriteDigitFile writes. WriteDigitFile returns the first error that
+// w returns. WriteDigitFile panics if count is negative.
+func WriteDigitFile(w io.Writer, n Number, count int, meta Metadata) error {
+	digits := n.WithSignificant(count)
+	hash := sha256.New()
+
+	// Writing to a hash never fails.
+	written, _ := digits.WriteTo(hash)
+	meta.Exponent = digits.Exponent()
+	meta.Count = int(written)
+	meta.Checksum = hex.EncodeToString(hash.Sum(nil))
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, kDigitFileHeader)
+	fmt.Fprintf(writer, "operation: %s\n", meta.Operation)
+	fmt.Fprintf(writer, "radicand: %s\n", meta.Radicand)
+	fmt.Fprintf(writer, "exponent: %d\n", meta.Exponent)
+	fmt.Fprintf(writer, "count: %d\n", meta.Count)
+	fmt.Fprintf(writer, "sha256: %s\n", meta.Checksum)
+	fmt.Fprintln(writer)
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return FormatBlocks(w, digits, kDigitFilePerGroup, kDigitFilePerLine)
+}
+
+// Fingerprint writes the first count significant digits of n to h as
+// ASCII characters '0' through '9' and returns the resulting hash. Two
+// machines can compare fingerprints to verify that they computed the same
+// digits without exchanging the digits. Fingerprint returns an error only
+// if h returns one. Fingerprint panics if count is negative.
+func Fingerprint(n Number, count int, h hash.Hash) ([]byte, error) {
+	if _, err := n.WithSignificant(count).WriteTo(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// ReadDigitFile reads a digit file that WriteDigitFile wrote and returns
+// its digits as a FiniteNumber along with its header. ReadDigitFile
+// returns an error if the digits do not match the count or checksum in
+// the header. ReadDigitFile also accepts plain digit dumps without a
+// header. In a plain dump, whitespace between digits is ignored, and an
+// optional decimal point determines the exponent. If a plain dump has no
+// decimal point, its digits are taken to follow one. For a plain dump,
+// ReadDigitFile computes Exponent, Count, and Checksum of the returned
+// Metadata and leaves the rest empty.
+func ReadDigitFile(r io.Reader) (*FiniteNumber, Metadata, error) {
+	reader := bufio.NewReader(r)
+	var meta Metadata
+	var text strings.Builder
+	started, header, inHeader := false, false, false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, Metadata{}, err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case !started && line == "":
+		case !started && line == kDigitFileHeader:
+			started, header, inHeader = true, true, true
+		case inHeader && line == "":
+			inHeader = false
+		case inHeader:
+			if parseErr := parseHeaderLine(line, &meta); parseErr != nil {
+				return nil, Metadata{}, parseErr
+			}
+		default:
+			started = true
+			fields := strings.Fields(line)
+			if header && len(fields) > 0 {
+
+				// Skip the position in the margin.
+				fields = fields[1:]
+			}
+			for _, field := range fields {
+				text.WriteString(field)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if header {
+		return finishDigitFile(text.String(), meta)
+	}
+	return finishPlainDump(text.String())
+}
+
+func parseHeaderLine(line string, meta *Metadata) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("sqrt: invalid header line %q", line)
+	}
+	value = strings.TrimSpace(value)
+	var err error
+	switch key {
+	case "operation":
+		meta.Operation = value
+	case "radicand":
+		meta.Radicand = value
+	case "exponent":
+		meta.Exponent, err = strconv.Atoi(value)
+	case "count":
+		meta.Count, err = strconv.Atoi(value)
+	case "sha256":
+		meta.Checksum = value
+	}
+	if err != nil {
+		return fmt.Errorf("sqrt: invalid header line %q", line)
+	}
+	return nil
+}
+
+func finishDigitFile(digits string, meta Metadata) (
+	*FiniteNumber, Metadata, error) {
+	if len(digits) != meta.Count {
+		return nil, Metadata{}, fmt.Errorf(
+			"sqrt: digit file has %d digits, header says %d",
+			len(digits), meta.Count)
+	}
+	if meta.Checksum != "" && meta.Checksum != digitChecksum(digits) {
+		return nil, Metadata{}, errors.New("sqrt: digit file checksum mismatch")
+	}
+	n, err := importDigitString(digits, meta.Exponent)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	return n, meta, nil
+}
+
+func finishPlainDump(text string) (*FiniteNumber, Metadata, error) {
+	intPart, fracPart, ok := strings.Cut(text, ".")
+	exponent := 0
+	if ok {
+		exponent = len(intPart)
+	}
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	exponent -= len(intPart+fracPart) - len(digits)
+	if digits == "" {
+		exponent = 0
+	}
+	n, err := importDigitString(digits, exponent)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	meta := Metadata{
+		Exponent: exponent,
+		Count:    len(digits),
+		Checksum: digitChecksum(digits),
+	}
+	return n, meta, nil
+}
+
+// importDigitString works like Import except that digits contains ASCII
+// characters '0' through '9'.
+func importDigitString(digits string, exp int) (*FiniteNumber, error) {
+	values := make([]byte, len(digits))
+	for i := range digits {
+		if digits[i] < '0' || digits[i] > '9' {
+			return nil, fmt.Errorf("sqrt: invalid digit %q", digits[i])
+		}
+		values[i] = digits[i] - '0'
+	}
+	return Import(values, exp)
+}
+
+func digitChecksum(digits string) string {
+	sum := sha256.Sum256([]byte(digits))
+	return hex.EncodeToString(sum[:])
+}
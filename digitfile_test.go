@@ -0,0 +1,149 @@
+package sqrt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteDigitFile(t *testing.T) {
+	var sb strings.Builder
+	err := WriteDigitFile(
+		&sb, Sqrt(2), 12, Metadata{Operation: "sqrt", Radicand: "2"})
+	assert.NoError(t, err)
+	expected := `# sqrt digit file
+operation: sqrt
+radicand: 2
+exponent: 1
+count: 12
+sha256: 6abe8bd2208def3fc4c8ce61c51c2f0fe33e2d2c37311df4b1176ef7f12c47e0
+
+0  1414213562 37
+`
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestWriteDigitFileFinite(t *testing.T) {
+	var sb strings.Builder
+	err := WriteDigitFile(&sb, Sqrt(256), 12, Metadata{Exponent: 99, Count: 7})
+	assert.NoError(t, err)
+	assert.Contains(t, sb.String(), "exponent: 2\ncount: 2\n")
+	assert.True(t, strings.HasSuffix(sb.String(), "\n0  16\n"))
+}
+
+func TestWriteDigitFileError(t *testing.T) {
+	w := &failingWriter{limit: 50}
+	assert.Equal(t, errWriteFailed, WriteDigitFile(w, Sqrt(2), 10, Metadata{}))
+	w = &failingWriter{limit: 1000}
+	err := WriteDigitFile(w, Sqrt(2), 10000, Metadata{})
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestWriteDigitFilePanics(t *testing.T) {
+	var sb strings.Builder
+	assert.Panics(t, func() { WriteDigitFile(&sb, Sqrt(2), -1, Metadata{}) })
+}
+
+func TestReadDigitFileRoundTrip(t *testing.T) {
+	var sb strings.Builder
+	meta := Metadata{Operation: "sqrt", Radicand: "2"}
+	assert.NoError(t, WriteDigitFile(&sb, Sqrt(2), 1234, meta))
+	n, actual, err := ReadDigitFile(strings.NewReader(sb.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, AsString(Sqrt(2).WithSignificant(1234)), AsString(n))
+	assert.Equal(t, 1, n.Exponent())
+	assert.Equal(t, "sqrt", actual.Operation)
+	assert.Equal(t, "2", actual.Radicand)
+	assert.Equal(t, 1, actual.Exponent)
+	assert.Equal(t, 1234, actual.Count)
+	assert.Len(t, actual.Checksum, 64)
+}
+
+func TestReadDigitFileKeepsTrailingZeros(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, WriteDigitFile(&sb, Sqrt(2), 21, Metadata{}))
+	n, _, err := ReadDigitFile(strings.NewReader(sb.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, "141421356237309504880", AsString(n))
+}
+
+func TestReadDigitFileEmpty(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, WriteDigitFile(&sb, Sqrt(2), 0, Metadata{}))
+	n, meta, err := ReadDigitFile(strings.NewReader(sb.String()))
+	assert.NoError(t, err)
+	assert.True(t, n.IsZero())
+	assert.Zero(t, meta.Count)
+}
+
+func TestReadDigitFileBadChecksum(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, WriteDigitFile(&sb, Sqrt(2), 12, Metadata{}))
+	corrupted := strings.Replace(sb.String(), "1414213562", "1414213563", 1)
+	_, _, err := ReadDigitFile(strings.NewReader(corrupted))
+	assert.Error(t, err)
+}
+
+func TestReadDigitFileBadCount(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, WriteDigitFile(&sb, Sqrt(2), 12, Metadata{}))
+	corrupted := strings.Replace(sb.String(), "count: 12", "count: 13", 1)
+	_, _, err := ReadDigitFile(strings.NewReader(corrupted))
+	assert.Error(t, err)
+}
+
+func TestReadDigitFileBadHeader(t *testing.T) {
+	text := "# sqrt digit file\nexponent: x\n\n0  1\n"
+	_, _, err := ReadDigitFile(strings.NewReader(text))
+	assert.Error(t, err)
+	text = "# sqrt digit file\nexponent\n\n0  1\n"
+	_, _, err = ReadDigitFile(strings.NewReader(text))
+	assert.Error(t, err)
+}
+
+func TestReadDigitFilePlain(t *testing.T) {
+	n, meta, err := ReadDigitFile(
+		strings.NewReader("\n1.4142135623 7309504880\n1688724209\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1414213562373095048801688724209", AsString(n))
+	assert.Equal(t, 1, n.Exponent())
+	assert.Equal(t, 1, meta.Exponent)
+	assert.Equal(t, 31, meta.Count)
+	assert.Equal(t, digitChecksum(AsString(n)), meta.Checksum)
+	assert.Empty(t, meta.Operation)
+}
+
+func TestReadDigitFilePlainNoPoint(t *testing.T) {
+	n, meta, err := ReadDigitFile(strings.NewReader("00141 42135"))
+	assert.NoError(t, err)
+	assert.Equal(t, "14142135", AsString(n))
+	assert.Equal(t, -2, n.Exponent())
+	assert.Equal(t, -2, meta.Exponent)
+}
+
+func TestReadDigitFilePlainErrors(t *testing.T) {
+	_, _, err := ReadDigitFile(strings.NewReader("1.41 42x"))
+	assert.Error(t, err)
+	_, _, err = ReadDigitFile(strings.NewReader("1.41.42"))
+	assert.Error(t, err)
+}
+
+func TestFingerprint(t *testing.T) {
+	sum, err := Fingerprint(Sqrt(2), 12, sha256.New())
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"6abe8bd2208def3fc4c8ce61c51c2f0fe33e2d2c37311df4b1176ef7f12c47e0",
+		hex.EncodeToString(sum))
+	other, err := Fingerprint(SqrtBigInt(big.NewInt(2)), 12, sha256.New())
+	assert.NoError(t, err)
+	assert.Equal(t, sum, other)
+	other, err = Fingerprint(Sqrt(2), 13, sha256.New())
+	assert.NoError(t, err)
+	assert.NotEqual(t, sum, other)
+	assert.Panics(t, func() { Fingerprint(Sqrt(2), -1, sha256.New()) })
+}
@@ -3,24 +3,133 @@ package sqrt
 import (
 	"context"
 	"math"
+	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 const (
 	kMemoizerChunkSize = 100
 	kMaxChunks         = math.MaxInt / kMemoizerChunkSize
+
+	// kDigitBlockSize is how many digits each block of a digitData holds.
+	kDigitBlockSize = 1 << 20
 )
 
+// digitData is an immutable view of memoized digits. The digits live in
+// blocks of kDigitBlockSize digits. Once a block is full, it never moves,
+// so adding digits never copies more than one block.
+type digitData struct {
+
+	// full holds the full blocks.
+	full [][]int8
+
+	// last holds the digits after the full blocks.
+	last []int8
+
+	// length is the number of digits in this view.
+	length int
+}
+
+// Len returns the number of digits in d.
+func (d digitData) Len() int {
+	return d.length
+}
+
+// At returns the digit at index. index must be between 0 and d.Len() - 1.
+func (d digitData) At(index int) int8 {
+	block := index / kDigitBlockSize
+	if block < len(d.full) {
+		return d.full[block][index%kDigitBlockSize]
+	}
+	return d.last[index-len(d.full)*kDigitBlockSize]
+}
+
+// Span returns the digits of d from start up to but not including end.
+// Span shares memory with d when the digits are all in one block and
+// copies them otherwise. Callers must not modify the returned slice.
+func (d digitData) Span(start, end int) []int8 {
+	end = min(end, d.length)
+	if start >= end {
+		return nil
+	}
+	block := d.block(start / kDigitBlockSize)
+	offset := start / kDigitBlockSize * kDigitBlockSize
+	if end-offset <= len(block) {
+		return block[start-offset : end-offset : end-offset]
+	}
+	result := make([]int8, end-start)
+	d.CopyTo(result, start)
+	return result
+}
+
+// CopyTo copies the digits of d starting at start into dst and returns
+// how many digits it copied.
+func (d digitData) CopyTo(dst []int8, start int) int {
+	count := 0
+	for count < len(dst) && start < d.length {
+		block := d.block(start / kDigitBlockSize)
+		offset := start % kDigitBlockSize
+		n := copy(dst[count:], block[offset:min(len(block), offset+d.length-start)])
+		count += n
+		start += n
+	}
+	return count
+}
+
+// WithMaxLength returns a view of the first n digits of d.
+func (d digitData) WithMaxLength(n int) digitData {
+	d.length = max(min(d.length, n), 0)
+	return d
+}
+
+func (d digitData) block(index int) []int8 {
+	if index < len(d.full) {
+		return d.full[index]
+	}
+	return d.last
+}
+
+// appendDigit returns d with x added to the end. d must include all the
+// digits so far. appendDigit never changes the digits that d or any
+// earlier view shows.
+func (d digitData) appendDigit(x int8) digitData {
+	if len(d.last) == kDigitBlockSize {
+		d.full = append(d.full, d.last)
+		d.last = make([]int8, 0, kDigitBlockSize)
+	}
+	d.last = append(d.last, x)
+	d.length++
+	return d
+}
+
 type digitMemoizer struct {
 	updateMu sync.Mutex
 	iter     func() int
 	readMu   sync.Mutex
-	data     []int8
+	data     digitData
 	done     bool
+	base     int
+
+	// held is how many bytes of digits this memoizer counts toward
+	// Stats.BytesHeld. It is separate so that the cleanup that subtracts
+	// it does not keep this memoizer reachable.
+	held *atomic.Int64
 }
 
 func newdigitMemoizer(iter func() int) *digitMemoizer {
-	return &digitMemoizer{iter: iter}
+	return newdigitMemoizerInBase(iter, 10)
+}
+
+// newdigitMemoizerInBase works like newdigitMemoizer except that the
+// digits iter returns are in base rather than in base 10.
+func newdigitMemoizerInBase(iter func() int, base int) *digitMemoizer {
+	result := &digitMemoizer{iter: iter, base: base, held: new(atomic.Int64)}
+	statsNumbersCreated.Add(1)
+	runtime.AddCleanup(result, func(held *atomic.Int64) {
+		statsBytesHeld.Add(-held.Load())
+	}, result.held)
+	return result
 }
 
 func (m *digitMemoizer) At(index int) int {
@@ -31,7 +140,30 @@ func (m *digitMemoizer) At(index int) int {
 	if !ok {
 		return -1
 	}
-	return int(data[index])
+	return int(data.At(index))
+}
+
+// AtMany sets values[i] to the digit at positions[i] for each i or to -1
+// if there is no such digit. AtMany waits only once for the largest
+// position in positions with limit being a cap on how far to wait.
+func (m *digitMemoizer) AtMany(positions, values []int, limit int) {
+	maxPosit := -1
+	for _, posit := range positions {
+		if posit < limit {
+			maxPosit = max(maxPosit, posit)
+		}
+	}
+	var data digitData
+	if m != nil && maxPosit >= 0 {
+		data, _ = m.wait(maxPosit)
+	}
+	for i, posit := range positions {
+		if posit < 0 || posit >= limit || posit >= data.Len() {
+			values[i] = -1
+		} else {
+			values[i] = int(data.At(posit))
+		}
+	}
 }
 
 func (m *digitMemoizer) Scan(
@@ -42,16 +174,17 @@ func (m *digitMemoizer) Scan(
 	if m == nil {
 		return
 	}
-	var data []int8
+	var block []int8
+	blockStart := 0
 	for start < end {
-		if start >= len(data) {
+		if start-blockStart >= len(block) {
 			var ok bool
-			data, ok = m.wait(start)
+			block, blockStart, ok = m.blockAt(start)
 			if !ok {
 				return
 			}
 		}
-		if !yield(start, int(data[start])) {
+		if !yield(start, int(block[start-blockStart])) {
 			return
 		}
 		start++
@@ -66,16 +199,17 @@ func (m *digitMemoizer) ScanValues(
 	if m == nil {
 		return
 	}
-	var data []int8
+	var block []int8
+	blockStart := 0
 	for start < end {
-		if start >= len(data) {
+		if start-blockStart >= len(block) {
 			var ok bool
-			data, ok = m.wait(start)
+			block, blockStart, ok = m.blockAt(start)
 			if !ok {
 				return
 			}
 		}
-		if !yield(int(data[start])) {
+		if !yield(int(block[start-blockStart])) {
 			return
 		}
 		start++
@@ -88,20 +222,30 @@ func (m *digitMemoizer) ReverseScan(
 		panic("start must be non-negative")
 	}
 	digits := m.firstN(end)
-	for index := len(digits) - 1; index >= start; index-- {
-		if !yield(index, int(digits[index])) {
+	for index := digits.Len() - 1; index >= start; index-- {
+		if !yield(index, int(digits.At(index))) {
 			return
 		}
 	}
 }
 
+// Data returns the memoized digits computing more digits as needed so that
+// the returned digits include the digit at index if it exists.
+func (m *digitMemoizer) Data(index int) digitData {
+	if m == nil || index < 0 {
+		return digitData{}
+	}
+	data, _ := m.wait(index)
+	return data
+}
+
 func (m *digitMemoizer) PrimeTo(ctx context.Context, upTo int) error {
 	if m == nil || upTo <= 0 {
 		return nil
 	}
 	data, done := m.get()
 	targetLength := getTargetLength(upTo - 1)
-	for !done && len(data) < targetLength {
+	for !done && data.Len() < targetLength {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -115,27 +259,44 @@ func (m *digitMemoizer) NumComputed() int {
 		return 0
 	}
 	data, _ := m.get()
-	return len(data)
+	return data.Len()
+}
+
+func (m *digitMemoizer) Done() bool {
+	if m == nil {
+		return true
+	}
+	_, done := m.get()
+	return done
 }
 
-func (m *digitMemoizer) firstN(n int) []int8 {
+func (m *digitMemoizer) firstN(n int) digitData {
 	if n <= 0 || m == nil {
-		return nil
+		return digitData{}
 	}
 	data, _ := m.wait(n - 1)
-	if len(data) > n {
-		return data[:n]
+	return data.WithMaxLength(n)
+}
+
+// blockAt returns the computed digits in the block containing index
+// along with the position of the first digit in that block. blockAt
+// returns false if there is no digit at index.
+func (m *digitMemoizer) blockAt(index int) ([]int8, int, bool) {
+	data, ok := m.wait(index)
+	if !ok {
+		return nil, 0, false
 	}
-	return data
+	blockStart := index / kDigitBlockSize * kDigitBlockSize
+	return data.Span(blockStart, blockStart+kDigitBlockSize), blockStart, true
 }
 
-func (m *digitMemoizer) get() ([]int8, bool) {
+func (m *digitMemoizer) get() (digitData, bool) {
 	m.readMu.Lock()
 	defer m.readMu.Unlock()
 	return m.data, m.done
 }
 
-func (m *digitMemoizer) put(data []int8, done bool) {
+func (m *digitMemoizer) put(data digitData, done bool) {
 	m.readMu.Lock()
 	defer m.readMu.Unlock()
 	m.data, m.done = data, done
@@ -151,29 +312,36 @@ func getTargetLength(index int) int {
 	return kMemoizerChunkSize * chunkCount
 }
 
-func (m *digitMemoizer) wait(index int) ([]int8, bool) {
+func (m *digitMemoizer) wait(index int) (digitData, bool) {
 	data, done := m.get()
 	targetLength := getTargetLength(index)
-	for !done && len(data) < targetLength {
+	for !done && data.Len() < targetLength {
 		data, done = m.grow(targetLength)
 	}
-	return data, len(data) > index
+	return data, data.Len() > index
 }
 
-func (m *digitMemoizer) grow(targetLength int) ([]int8, bool) {
+func (m *digitMemoizer) grow(targetLength int) (digitData, bool) {
 	m.updateMu.Lock()
 	defer m.updateMu.Unlock()
 	data, done := m.get()
-	if !done && len(data) < targetLength {
+	if !done && data.Len() < targetLength {
+		statsGoroutines.Add(1)
+		defer statsGoroutines.Add(-1)
+		before := data.Len()
 		for range kMemoizerChunkSize {
 			x := m.iter()
-			if digitOutOfRange(x) {
+			if x < 0 || x >= m.base {
 				done = true
 				break
 			}
-			data = append(data, int8(x))
+			data = data.appendDigit(int8(x))
 		}
 		m.put(data, done)
+		computed := int64(data.Len() - before)
+		m.held.Add(computed)
+		statsDigitsComputed.Add(computed)
+		statsBytesHeld.Add(computed)
 	}
 	return data, done
 }
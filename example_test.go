@@ -22,6 +22,22 @@ func ExampleCubeRoot() {
 	// 1.442249570307408382321638310780109588391869253499350577546416194541687596829997339854755479705645256
 }
 
+func ExampleExp() {
+
+	// Print e raised to the square root of 2 with 50 significant digits.
+	fmt.Printf("%.50g\n", sqrt.Exp(sqrt.Sqrt(2)))
+	// Output:
+	// 4.1132503787829275171735818151403045024016639431511
+}
+
+func ExampleLog() {
+
+	// Print the natural logarithm of 2 with 50 significant digits.
+	fmt.Printf("%.50g\n", sqrt.Log(sqrt.Sqrt(4)))
+	// Output:
+	// 0.69314718055994530941723212145817656807550013436025
+}
+
 func ExampleNewNumberForTesting() {
 
 	// n = 10.2003400340034...
@@ -164,3 +180,11 @@ func ExampleFiniteNumber_NumComputed() {
 	// 7
 	// true
 }
+
+func ExampleMask() {
+
+	// Reveal only the first 5 significant digits of sqrt(2).
+	fmt.Printf("%.10f\n", sqrt.Mask(sqrt.Sqrt(2), 5, '#'))
+	// Output:
+	// 1.4142######
+}
@@ -0,0 +1,193 @@
+package sqrt
+
+import (
+	"math/big"
+	"strconv"
+)
+
+const (
+	kExpGuardDigits = 10
+	kLogGuardDigits = 10
+)
+
+var (
+	three  = big.NewInt(3)
+	ratOne = big.NewRat(1, 1)
+)
+
+// Exp returns e raised to the power of n. The digits of the returned Number
+// are computed lazily from rigorous lower and upper bounds of the result.
+// Because of this, if the result has a finite number of digits, computing
+// its last digit never finishes, and if the result is a power of 10, Exp
+// itself never finishes. Neither can happen when n is rational. Exp of a
+// Number that Log returned is the Number passed to Log, so Exp(Log(x))
+// always finishes.
+func Exp(n Number) Number {
+	if n.IsZero() {
+		return newFiniteNumber(newRepeatingGenerator([]int{1}, nil, 1).Generate())
+	}
+	if nn, ok := n.(*number); ok && nn.logOf != nil {
+		return nn.logOf
+	}
+	return &number{
+		numberPart: newnumberPart(newIntervalGenerator(
+			func(k int) (lo, hi *big.Int) {
+				return expBounds(n, k)
+			}).Generate()),
+		expOf: n,
+	}
+}
+
+// Log returns the natural logarithm of n. Because Number can only hold
+// non-negative values, Log panics if n is less than 1. The digits of the
+// returned Number are computed lazily from rigorous lower and upper bounds
+// of the result. Because of this, if the result has a finite number of
+// digits, computing its last digit never finishes, and if the result is a
+// power of 10, Log itself never finishes. Neither can happen when n is
+// rational. Log of a Number that Exp returned is the Number passed to Exp,
+// so Log(Exp(x)) always finishes.
+func Log(n Number) Number {
+	if n.IsZero() {
+		panic("n must be at least 1")
+	}
+	if nn, ok := n.(*number); ok && nn.expOf != nil {
+		return nn.expOf
+	}
+
+	// Compare by value so that 1 with trailing zeros or 0.999... count as 1.
+	if r, ok := n.AsRat(); ok {
+		switch cmp := r.Cmp(ratOne); {
+		case cmp < 0:
+			panic("n must be at least 1")
+		case cmp == 0:
+			return &FiniteNumber{}
+		}
+	} else if n.Exponent() < 1 {
+		panic("n must be at least 1")
+	}
+	return &number{
+		numberPart: newnumberPart(newIntervalGenerator(
+			func(k int) (lo, hi *big.Int) {
+				return logBounds(n, k)
+			}).Generate()),
+		logOf: n,
+	}
+}
+
+// expBounds returns lo and hi such that lo <= exp(n)*10^k <= hi.
+func expBounds(n Number, k int) (lo, hi *big.Int) {
+	xlo, xhi := bracket(n, max(k+n.Exponent()+kExpGuardDigits, 1))
+	squarings := squaringsForExp(xhi)
+	guard := kExpGuardDigits + squarings/3
+	scale := pow10(k + guard)
+	lo = expScaled(xlo, scale, squarings, false)
+	hi = expScaled(xhi, scale, squarings, true)
+	shift := pow10(guard)
+	return divRound(lo, shift, false), divRound(hi, shift, true)
+}
+
+// logBounds returns lo and hi such that lo <= ln(n)*10^k <= hi. n must be
+// at least 1.
+func logBounds(n Number, k int) (lo, hi *big.Int) {
+	xlo, xhi := bracket(n, max(k+n.Exponent()+kLogGuardDigits, 1))
+	guard := kLogGuardDigits + len(strconv.Itoa(n.Exponent()))
+	scale := pow10(k + guard)
+	lo = logScaled(xlo, scale, false)
+	hi = logScaled(xhi, scale, true)
+	shift := pow10(guard)
+	return divRound(lo, shift, false), divRound(hi, shift, true)
+}
+
+// squaringsForExp returns r such that x / 2^r < 1/256.
+func squaringsForExp(x *big.Rat) int {
+	ceil := divRound(new(big.Int).Set(x.Num()), x.Denom(), true)
+	return ceil.BitLen() + 8
+}
+
+// expScaled returns exp(x)*scale rounded down or, if up is true, rounded
+// up. x must be non-negative and x / 2^squarings must be less than 1/256.
+func expScaled(x *big.Rat, scale *big.Int, squarings int, up bool) *big.Int {
+	denom := new(big.Int).Lsh(x.Denom(), uint(squarings))
+	term := new(big.Int).Set(scale)
+	sum := new(big.Int)
+	for i := int64(1); ; i++ {
+		if up && term.Cmp(one) <= 0 {
+
+			// The remaining terms sum to no more than twice this term.
+			sum.Add(sum, term).Add(sum, term)
+			break
+		}
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+		term.Mul(term, x.Num())
+		divRound(term, new(big.Int).Mul(denom, big.NewInt(i)), up)
+	}
+	for range squarings {
+		sum.Mul(sum, sum)
+		divRound(sum, scale, up)
+	}
+	return sum
+}
+
+// logScaled returns ln(x)*scale rounded down or, if up is true, rounded
+// up. x must be at least 1.
+func logScaled(x *big.Rat, scale *big.Int, up bool) *big.Int {
+
+	// x = m * 2^pow2 where 1 <= m < 2.
+	pow2 := x.Num().BitLen() - x.Denom().BitLen()
+	shiftedDenom := new(big.Int).Lsh(x.Denom(), uint(pow2))
+	if x.Num().Cmp(shiftedDenom) < 0 {
+		pow2--
+		shiftedDenom.Rsh(shiftedDenom, 1)
+	}
+
+	// ln(m) = 2*atanh((m-1)/(m+1)) and ln(2) = 2*atanh(1/3)
+	result := atanhScaled(
+		new(big.Int).Sub(x.Num(), shiftedDenom),
+		new(big.Int).Add(x.Num(), shiftedDenom),
+		scale,
+		up)
+	if pow2 > 0 {
+		ln2 := atanhScaled(one, three, scale, up)
+		result.Add(result, ln2.Mul(ln2, big.NewInt(int64(pow2))))
+	}
+	return result.Lsh(result, 1)
+}
+
+// atanhScaled returns atanh(num/denom)*scale rounded down or, if up is true,
+// rounded up. num/denom must be between 0 and 1/3 inclusive.
+func atanhScaled(num, denom, scale *big.Int, up bool) *big.Int {
+	num2 := new(big.Int).Mul(num, num)
+	denom2 := new(big.Int).Mul(denom, denom)
+	power := divRound(new(big.Int).Mul(scale, num), denom, up)
+	sum := new(big.Int)
+	var term big.Int
+	for i := int64(1); ; i += 2 {
+		if up && power.Cmp(one) <= 0 {
+
+			// The remaining terms sum to no more than twice this power.
+			sum.Add(sum, power).Add(sum, power)
+			break
+		}
+		if power.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, divRound(term.Set(power), big.NewInt(i), up))
+		power.Mul(power, num2)
+		divRound(power, denom2, up)
+	}
+	return sum
+}
+
+// divRound sets x to x / d rounded down or, if up is true, rounded up and
+// returns x. x must be non-negative and d must be positive.
+func divRound(x, d *big.Int, up bool) *big.Int {
+	var m big.Int
+	x.DivMod(x, d, &m)
+	if up && m.Sign() != 0 {
+		x.Add(x, one)
+	}
+	return x
+}
@@ -0,0 +1,106 @@
+package sqrt
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExp(t *testing.T) {
+	n := Exp(Sqrt(2))
+	assert.Equal(t, 1, n.Exponent())
+	assert.Equal(
+		t,
+		"4.11325037878292751717358181514030450240166394315110961006836",
+		fmt.Sprintf("%.60g", n))
+}
+
+func TestExpOne(t *testing.T) {
+	assert.Equal(
+		t,
+		"2.71828182845904523536028747135266249775724709369995957496696",
+		fmt.Sprintf("%.60g", Exp(Sqrt(1))))
+}
+
+func TestExpLarge(t *testing.T) {
+	n := Exp(Sqrt(10000))
+	assert.Equal(t, 44, n.Exponent())
+	assert.Equal(
+		t,
+		"26881171418161354484126255515800135873611118.7737419224",
+		fmt.Sprintf("%.10f", n))
+}
+
+func TestExpSmall(t *testing.T) {
+	assert.Equal(
+		t,
+		"1.00001000005000016666708333416666805555753968501984402557594",
+		fmt.Sprintf("%.60g", Exp(SqrtRat(1, 10000000000))))
+}
+
+func TestExpZero(t *testing.T) {
+	assert.Equal(t, "1", Exp(zeroNumber).String())
+}
+
+func TestExpDeepDigit(t *testing.T) {
+	assert.Equal(t, 8, Exp(Sqrt(3)).At(1999))
+}
+
+func TestLog(t *testing.T) {
+	assert.Equal(
+		t,
+		"0.693147180559945309417232121458176568075500134360255254120680",
+		fmt.Sprintf("%.60g", Log(Sqrt(4))))
+	assert.Equal(
+		t,
+		"6.90775527898213705205397436405309262280330446588631892809998",
+		fmt.Sprintf("%.60g", Log(Sqrt(1000000))))
+}
+
+func TestLogNearOne(t *testing.T) {
+	n := Log(SqrtRat(100000001, 100000000))
+	assert.Equal(t, -8, n.Exponent())
+	assert.Equal(
+		t,
+		"0.499999997500000016666666541666667666666658333333404761904136e-08",
+		fmt.Sprintf("%.60e", n))
+}
+
+func TestLogOne(t *testing.T) {
+	assert.Equal(t, zeroNumber, Log(Sqrt(1)))
+	assert.Equal(t, zeroNumber, Log(MustFinite([]int{1, 0, 0}, 1)))
+	assert.Equal(t, zeroNumber, Log(MustNumber([]int{1}, []int{0}, 1)))
+
+	// 0.999... is 1
+	assert.Equal(t, zeroNumber, Log(MustNumber(nil, []int{9}, 0)))
+}
+
+func TestExpLogPowerOfTen(t *testing.T) {
+	done := make(chan Number)
+	go func() {
+		done <- Exp(Log(Sqrt(100)))
+	}()
+	select {
+	case n := <-done:
+		assert.Equal(t, "10", n.String())
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "Exp(Log(Sqrt(100))) did not finish")
+	}
+	go func() {
+		done <- Log(Exp(Sqrt(1)))
+	}()
+	select {
+	case n := <-done:
+		assert.Equal(t, "1", n.String())
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "Log(Exp(Sqrt(1))) did not finish")
+	}
+}
+
+func TestLogPanics(t *testing.T) {
+	assert.Panics(t, func() { Log(Sqrt(0)) })
+	assert.Panics(t, func() { Log(SqrtRat(1, 2)) })
+	assert.Panics(t, func() { Log(MustNumber(nil, []int{9}, -1)) })
+}
@@ -0,0 +1,227 @@
+package sqrt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Mask returns a fmt.Formatter that prints n just as n itself would be
+// printed except that each significant digit at zero based position
+// visible and beyond prints as mask. Width and precision work the same
+// as for n. Mask is useful when revealing the digits of a Number
+// progressively. Mask panics if visible is negative.
+func Mask(n Number, visible int, mask rune) fmt.Formatter {
+	if visible < 0 {
+		panic("visible must be non-negative")
+	}
+	return &maskedNumber{n: n, visible: visible, mask: mask}
+}
+
+type maskedNumber struct {
+	n       Number
+	visible int
+	mask    rune
+}
+
+func (m *maskedNumber) Format(state fmt.State, verb rune) {
+	m.n.impl().formatWithOptions(state, verb, formatOptions{
+		maskFrom: m.visible, maskChar: m.mask, source: m.n})
+}
+
+// FormatOptions customizes how Numbers print beyond what the fmt verbs and
+// flags offer. The zero value prints Numbers the usual way.
+type FormatOptions struct {
+
+	// IntegerSeparator, if non-empty, goes between each group of three
+	// digits before the decimal point, for example "1,414,213.56".
+	IntegerSeparator string
+
+	// FractionGroupSize, if positive, is the number of digits in each
+	// group of digits after the decimal point.
+	FractionGroupSize int
+
+	// FractionSeparator goes between each group of digits after the
+	// decimal point. FractionSeparator has no effect unless
+	// FractionGroupSize is positive.
+	FractionSeparator string
+
+	// NormalizedScientific, if true, makes scientific notation follow the
+	// convention of strconv.FormatFloat, which puts one digit before the
+	// decimal point, for example "1.414214e+00" instead of "0.141421e+01".
+	// With the e and E verbs, precision is then the number of digits after
+	// the decimal point, the same as for float64.
+	NormalizedScientific bool
+
+	// ExponentDigits, if positive, is the minimum number of digits in the
+	// exponent of scientific notation. Exponents with fewer digits get
+	// leading zeros. The default is 2.
+	ExponentDigits int
+
+	// Rounding is how to round the last printed digit. The default,
+	// RoundDown, is how Numbers normally print. Rounding has no effect on
+	// the x, X, and b verbs, which always round down.
+	Rounding RoundingMode
+
+	// DefaultPrecision, if positive, is the number of significant digits
+	// that the g, G, and v verbs show when the format has no precision.
+	// The default is 16, the same as String uses. DefaultPrecision cannot
+	// change what a Number's own String method returns. A Number does not
+	// carry FormatOptions, and this package has no Context to hold a
+	// default, so String always shows 16 digits. Instead, n.FormatWith
+	// with these options returns what String would return with
+	// DefaultPrecision digits.
+	DefaultPrecision int
+
+	// SigDigits, if positive, is the number of significant digits that
+	// FormatWith and Fprint show. The default is DefaultPrecision. The
+	// fmt verbs ignore SigDigits, Scientific, Capital, and ExactDigitCount
+	// because the verb and precision already say these things.
+	SigDigits int
+
+	// Scientific, if true, makes FormatWith and Fprint always use
+	// scientific notation. Otherwise, they use scientific notation only
+	// when the g verb would.
+	Scientific bool
+
+	// Capital, if true, makes scientific notation use E instead of e.
+	Capital bool
+
+	// ExactDigitCount, if true, makes FormatWith and Fprint show exactly
+	// SigDigits significant digits, padding with trailing zeros if
+	// needed, the same way the e verb does.
+	ExactDigitCount bool
+}
+
+// RoundingMode is a way to round Numbers.
+type RoundingMode int
+
+const (
+
+	// RoundDown rounds toward zero.
+	RoundDown RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest value. If there is a tie, it
+	// rounds to the value whose last digit is even.
+	RoundHalfEven
+
+	// RoundHalfAway rounds to the nearest value. If there is a tie, it
+	// rounds away from zero.
+	RoundHalfAway
+)
+
+// Formatter returns a fmt.Formatter that prints n with the verbs, width,
+// precision, and flags that n supports but according to these options.
+func (o FormatOptions) Formatter(n Number) fmt.Formatter {
+	return &optionsNumber{n: n.impl(), options: o.internal(n)}
+}
+
+// Fprint writes n to w the same way that n.FormatWith(opts) formats it.
+// Unlike fmt.Fprint, Fprint returns the first error that w returns.
+func Fprint(w io.Writer, n Number, opts FormatOptions) error {
+	formatSpec, target := opts.spec(n)
+	cw := &countingWriter{w: w}
+	formatSpec.PrintNumber(cw, target)
+	return cw.err
+}
+
+func formatWith(n Number, opts FormatOptions) string {
+	var builder strings.Builder
+	formatSpec, target := opts.spec(n)
+	formatSpec.PrintNumber(&builder, target)
+	return builder.String()
+}
+
+// spec returns the formatSpec for printing n according to these options
+// along with the digits to print, which differ from n when rounding.
+func (o FormatOptions) spec(n Number) (formatSpec, *numberPart) {
+	sigDigits := o.SigDigits
+	if sigDigits <= 0 {
+		sigDigits = o.DefaultPrecision
+	}
+	if sigDigits <= 0 {
+		sigDigits = gPrecision
+	}
+	target := n.impl()
+	if o.Rounding != RoundDown {
+		target = &round(n, sigDigits, o.Rounding).numberPart
+	}
+	sci := o.Scientific ||
+		sigDigits < target.exponent ||
+		bigExponent(target.exponent)
+	return formatSpec{
+		sigDigits:       sigDigits,
+		exactDigitCount: o.ExactDigitCount,
+		sci:             sci,
+		capital:         o.Capital,
+		formatOptions:   o.internal(n),
+	}, target
+}
+
+func (o FormatOptions) internal(n Number) formatOptions {
+	return formatOptions{
+		intSep:           o.IntegerSeparator,
+		fracGroup:        o.FractionGroupSize,
+		fracSep:          o.FractionSeparator,
+		normalizedSci:    o.NormalizedScientific,
+		expDigits:        o.ExponentDigits,
+		rounding:         o.Rounding,
+		source:           n,
+		defaultPrecision: o.DefaultPrecision,
+	}
+}
+
+type optionsNumber struct {
+	n       *numberPart
+	options formatOptions
+}
+
+func (o *optionsNumber) Format(state fmt.State, verb rune) {
+	o.n.formatWithOptions(state, verb, o.options)
+}
+
+// FormatBlocks writes the digits of s to w in the layout of published
+// digit tables. Each line has perLine digits in groups of perGroup digits
+// separated by spaces. The 0 based position of the first digit on each
+// line appears in the margin. FormatBlocks returns the first error that w
+// returns. FormatBlocks panics if perGroup or perLine is not positive or
+// if perLine is not a multiple of perGroup.
+func FormatBlocks(w io.Writer, s FiniteSequence, perGroup, perLine int) error {
+	if perGroup <= 0 || perLine <= 0 {
+		panic("perGroup and perLine must be positive")
+	}
+	if perLine%perGroup != 0 {
+		panic("perLine must be a multiple of perGroup")
+	}
+	first, last := -1, -1
+	for posit := range s.All() {
+		first = posit
+		break
+	}
+	if first == -1 {
+		return nil
+	}
+	for posit := range s.Backward() {
+		last = posit
+		break
+	}
+	marginWidth := len(strconv.Itoa(first + (last-first)/perLine*perLine))
+	writer := bufio.NewWriter(w)
+	for posit, digit := range s.All() {
+		count := posit - first
+		switch {
+		case count%perLine == 0:
+			if count > 0 {
+				writer.WriteByte('\n')
+			}
+			fmt.Fprintf(writer, "%*d  ", marginWidth, posit)
+		case count%perGroup == 0:
+			writer.WriteByte(' ')
+		}
+		writer.WriteByte('0' + byte(digit))
+	}
+	writer.WriteByte('\n')
+	return writer.Flush()
+}
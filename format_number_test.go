@@ -3,7 +3,12 @@ package sqrt
 import (
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -261,6 +266,40 @@ func TestNumberBadVerb(t *testing.T) {
 	assert.Equal(t, "%!h(number=12345.6789)", actual)
 }
 
+func TestMask(t *testing.T) {
+	number := fakeNumber().withExponent(5)
+	actual := fmt.Sprintf("%f", Mask(number, 7, '#'))
+	assert.Equal(t, "12345.67####", actual)
+	actual = fmt.Sprintf("%.3f", Mask(number, 0, '#'))
+	assert.Equal(t, "#####.###", actual)
+	actual = fmt.Sprintf("%.3e", Mask(number, 2, '#'))
+	assert.Equal(t, "0.12#e+05", actual)
+	actual = fmt.Sprintf("%v", Mask(number, 20, '#'))
+	assert.Equal(t, "12345.67890123456", actual)
+	actual = fmt.Sprintf("%h", Mask(number, 3, '#'))
+	assert.Equal(t, "%!h(number=123##.###########)", actual)
+}
+
+func TestMaskNegExponent(t *testing.T) {
+	number := fakeNumber().WithSignificant(4).withExponent(-2)
+	actual := fmt.Sprintf("%.8f", Mask(number, 2, '#'))
+	assert.Equal(t, "0.0012####", actual)
+	actual = fmt.Sprintf("%g", Mask(number, 2, '#'))
+	assert.Equal(t, "0.0012##", actual)
+}
+
+func TestMaskWidth(t *testing.T) {
+	number := fakeNumber().withExponent(1)
+	actual := fmt.Sprintf("%10.5f", Mask(number, 3, '…'))
+	assert.Equal(t, "   1.23………", actual)
+	actual = fmt.Sprintf("%-10.5f", Mask(number, 3, '…'))
+	assert.Equal(t, "1.23………   ", actual)
+}
+
+func TestMaskPanics(t *testing.T) {
+	assert.Panics(t, func() { Mask(fakeNumber(), -1, '#') })
+}
+
 type maxBytesWriter struct {
 	maxBytes     int
 	bytesWritten int
@@ -276,3 +315,518 @@ func (m *maxBytesWriter) Write(p []byte) (n int, err error) {
 	m.bytesWritten += diff
 	return diff, errors.New("Ran out of space")
 }
+
+func TestFormatHex(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "1.6a09e6", fmt.Sprintf("%x", n))
+	assert.Equal(t, "1.6a09e667f3bcc908b2fb", fmt.Sprintf("%.20x", n))
+	assert.Equal(t, "1.6A09E667F3BCC908B2FB", fmt.Sprintf("%.20X", n))
+	assert.Equal(t, "1f.9f6e4990", fmt.Sprintf("%.8x", Sqrt(1000)))
+	assert.Equal(t, "0.08186e2750", fmt.Sprintf("%.10x", InvSqrt(1000)))
+	assert.Equal(t, "  1.6a0|", fmt.Sprintf("%7.3x|", n))
+	assert.Equal(t, "1.6a0  |", fmt.Sprintf("%-7.3x|", n))
+	assert.Equal(t, "1", fmt.Sprintf("%.0x", n))
+}
+
+func TestFormatBinary(t *testing.T) {
+	assert.Equal(
+		t, "1.01101010000010011110", fmt.Sprintf("%.20b", Sqrt(2)))
+	assert.Equal(t, "1010.000", fmt.Sprintf("%.3b", Sqrt(100)))
+}
+
+func TestFormatBaseFinite(t *testing.T) {
+	n, _ := NewFiniteNumber([]int{3}, -2)
+	assert.Equal(t, "0.00c49ba5e3", fmt.Sprintf("%.10x", n))
+	n, _ = NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1.40000", fmt.Sprintf("%.5x", n))
+	assert.Equal(t, "1.010000", fmt.Sprintf("%b", n))
+	assert.Equal(t, "0.000000", fmt.Sprintf("%x", zeroNumber))
+	assert.Equal(t, "1.55555", fmt.Sprintf("%.5x", SqrtRat(16, 9)))
+}
+
+func TestFormatBasePeriodic(t *testing.T) {
+	one := MustNumber(nil, []int{9}, 0)
+	assert.Equal(t, "1.000000", fmt.Sprintf("%x", one))
+	assert.Equal(t, "1.000", fmt.Sprintf("%.3b", one))
+	assert.Equal(t, "1.00", string(one.AppendFormat(nil, 'x', 2)))
+	assert.Equal(t, "10.00i", fmt.Sprintf("%.2x", Imaginary(
+		MustNumber([]int{1, 5}, []int{9}, 2))))
+	assert.Equal(t, "1.**", fmt.Sprintf("%.2x", Mask(one, 1, '*')))
+	assert.Equal(t, "0.5555", fmt.Sprintf("%.4x", MustNumber(nil, []int{3}, 0)))
+}
+
+func TestFormatBaseUndecided(t *testing.T) {
+
+	// Numbers with infinitely many digits that do not know their digits
+	// repeat. Their values, 16 and 0.5, have short hex digits that the
+	// decimal digits never decide, so the hex digits come from truncating.
+	sixteen := NewNumber(
+		SequenceGenerator(MustNumber([]int{1, 5}, []int{9}, 2), 2))
+	half := NewNumber(
+		SequenceGenerator(MustNumber([]int{4}, []int{9}, 0), 0))
+	done := make(chan [2]string)
+	go func() {
+		done <- [2]string{
+			fmt.Sprintf("%.4x", sixteen), fmt.Sprintf("%.4x", half)}
+	}()
+	select {
+	case actual := <-done:
+		assert.Equal(t, [2]string{"f.ffff", "0.7fff"}, actual)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "formatting in hex did not finish")
+	}
+}
+
+func TestFormatEllipsis(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "1.4142...", fmt.Sprintf("%#.5g", n))
+	assert.Equal(t, "1.414213...", fmt.Sprintf("%#f", n))
+	assert.Equal(t, "0.14142e+01...", fmt.Sprintf("%#.5e", n))
+	assert.Equal(t, "0.14142E+01...", fmt.Sprintf("%#.5E", n))
+	assert.Equal(t, "0.1414e+01...i", fmt.Sprintf("%#.4e", Imaginary(n)))
+	assert.Equal(t, "   1.41...|", fmt.Sprintf("%#10.2f|", n))
+	assert.Equal(t, "0.0...", fmt.Sprintf("%#.1f", InvSqrt(1000000)))
+	assert.Equal(t, "1.4142", fmt.Sprintf("%.5g", n))
+}
+
+func TestFormatEllipsisFinite(t *testing.T) {
+	n, _ := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1.25", fmt.Sprintf("%#g", n))
+	assert.Equal(t, "1.25000", fmt.Sprintf("%#.5f", n))
+	assert.Equal(t, "1.2...", fmt.Sprintf("%#.1f", n))
+	assert.Equal(t, "0b1.01", fmt.Sprintf("%#.2b", n))
+	assert.Equal(t, "0", fmt.Sprintf("%#g", zeroNumber))
+}
+
+func TestFormatRadixPrefix(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "0x1.6a0", fmt.Sprintf("%#.3x", n))
+	assert.Equal(t, "0X1.6A0", fmt.Sprintf("%#.3X", n))
+	assert.Equal(t, "0b1.011", fmt.Sprintf("%#.3b", n))
+	assert.Equal(t, "  0x1.6a0|", fmt.Sprintf("%#9.3x|", n))
+	assert.Equal(t, "0x001.6a0|", fmt.Sprintf("%#09.3x|", n))
+	assert.Equal(t, "+0x1.6a0", fmt.Sprintf("%+#.3x", n))
+}
+
+func TestFormatSignFlags(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "+1.414", fmt.Sprintf("%+.4g", n))
+	assert.Equal(t, " 1.414", fmt.Sprintf("% .4g", n))
+	assert.Equal(t, "+1.414", fmt.Sprintf("%+ .4g", n))
+	assert.Equal(t, "  +1.414", fmt.Sprintf("%+8.4g", n))
+	assert.Equal(t, " 1.414  |", fmt.Sprintf("%- 8.4g|", n))
+	assert.Equal(t, "+0.000", fmt.Sprintf("%+.3f", zeroNumber))
+	assert.Equal(t, "+1.6a0", fmt.Sprintf("%+.3x", n))
+	assert.Equal(
+		t,
+		fmt.Sprintf("% .3f", 1.41421),
+		fmt.Sprintf("% .3f", n))
+}
+
+func TestFormatOptionsGrouping(t *testing.T) {
+	options := FormatOptions{IntegerSeparator: ","}
+	assert.Equal(
+		t,
+		"1,414,213.56",
+		fmt.Sprintf("%.2f", options.Formatter(Sqrt(2000000000000))))
+	assert.Equal(
+		t, "141,421.3", fmt.Sprintf("%.1f", options.Formatter(Sqrt(20000000000))))
+	assert.Equal(t, "141.42", fmt.Sprintf("%.2f", options.Formatter(Sqrt(20000))))
+	assert.Equal(t, "0.141", fmt.Sprintf("%.3f", options.Formatter(SqrtRat(2, 100))))
+	n, _ := NewFiniteNumber([]int{1}, 7)
+	assert.Equal(t, "1,000,000", fmt.Sprintf("%.0f", options.Formatter(n)))
+	assert.Equal(
+		t, "  1,000,000|", fmt.Sprintf("%11.0f|", options.Formatter(n)))
+}
+
+func TestFormatOptionsFractionGroups(t *testing.T) {
+	options := FormatOptions{
+		IntegerSeparator:  ",",
+		FractionGroupSize: 5,
+		FractionSeparator: " ",
+	}
+	assert.Equal(
+		t,
+		"1.41421 35623 73095",
+		fmt.Sprintf("%.15f", options.Formatter(Sqrt(2))))
+	assert.Equal(
+		t,
+		"1,414.21356 2",
+		fmt.Sprintf("%.6f", options.Formatter(Sqrt(2000000))))
+	assert.Equal(
+		t,
+		"0.00001 41421",
+		fmt.Sprintf("%.10f", options.Formatter(SqrtRat(2, 10000000000))))
+	assert.Equal(
+		t,
+		"0.00000 00",
+		fmt.Sprintf("%.7f", options.Formatter(zeroNumber)))
+	assert.Equal(
+		t,
+		"0.14142 1e+01",
+		fmt.Sprintf("%.6e", options.Formatter(Sqrt(2))))
+}
+
+func TestFormatOptionsZero(t *testing.T) {
+	var options FormatOptions
+	assert.Equal(
+		t,
+		fmt.Sprintf("%10.3f", Sqrt(2)),
+		fmt.Sprintf("%10.3f", options.Formatter(Sqrt(2))))
+}
+
+func TestFormatOptionsNormalizedScientific(t *testing.T) {
+	options := FormatOptions{NormalizedScientific: true}
+	sqrt2 := options.Formatter(Sqrt(2))
+	assert.Equal(t, "1.414213e+00", fmt.Sprintf("%e", sqrt2))
+	assert.Equal(t, "1.41E+00", fmt.Sprintf("%.2E", sqrt2))
+	assert.Equal(t, "1e+00", fmt.Sprintf("%.0e", sqrt2))
+	assert.Equal(
+		t,
+		"1.414213562e+09",
+		fmt.Sprintf("%.9e", options.Formatter(Sqrt(2000000000000000000))))
+	assert.Equal(
+		t,
+		"1.414e-05",
+		fmt.Sprintf("%.3e", options.Formatter(SqrtRat(2, 10000000000))))
+	assert.Equal(
+		t,
+		"1.414e+09",
+		fmt.Sprintf("%.4g", options.Formatter(Sqrt(2000000000000000000))))
+	zero := options.Formatter(zeroNumber)
+	assert.Equal(t, "0.000000e+00", fmt.Sprintf("%e", zero))
+	assert.Equal(t, fmt.Sprintf("%e", 0.0), fmt.Sprintf("%e", zero))
+}
+
+func TestFormatOptionsExponentDigits(t *testing.T) {
+	options := FormatOptions{ExponentDigits: 4}
+	assert.Equal(
+		t, "0.141e+0001", fmt.Sprintf("%.3e", options.Formatter(Sqrt(2))))
+	assert.Equal(
+		t,
+		"0.141e-0004",
+		fmt.Sprintf("%.3e", options.Formatter(SqrtRat(2, 10000000000))))
+	n := SqrtScaled(big.NewInt(2), 1000000)
+	assert.Equal(t, "0.141e+500001", fmt.Sprintf("%.3e", options.Formatter(n)))
+	assert.Equal(t, "0.141e+500001", fmt.Sprintf("%.3e", n))
+	assert.Equal(
+		t,
+		"  0.141E+0001|",
+		fmt.Sprintf("%13.3E|", options.Formatter(Sqrt(2))))
+	assert.Equal(
+		t, "0.141e+01", fmt.Sprintf("%.3e", FormatOptions{}.Formatter(Sqrt(2))))
+	options.NormalizedScientific = true
+	assert.Equal(
+		t, "1.414e+0000", fmt.Sprintf("%.3e", options.Formatter(Sqrt(2))))
+}
+
+func TestAppendFormat(t *testing.T) {
+	n := Sqrt(2)
+	buf := []byte("x=")
+	assert.Equal(t, "x=1.414", string(n.AppendFormat(buf, 'g', 4)))
+	for _, verb := range []rune{'f', 'F', 'g', 'G', 'e', 'E', 'v', 'x', 'X', 'b'} {
+		for _, precision := range []int{0, 3, 20} {
+			assert.Equal(
+				t,
+				fmt.Sprintf("%.*"+string(verb), precision, n),
+				string(n.AppendFormat(nil, verb, precision)))
+		}
+		assert.Equal(
+			t,
+			fmt.Sprintf("%"+string(verb), n),
+			string(n.AppendFormat(nil, verb, -1)))
+	}
+	large := SqrtBigInt(big.NewInt(2000000000000000000))
+	assert.Equal(t, "0.14142E+10", string(large.AppendFormat(nil, 'E', 5)))
+	assert.Equal(t, "0", string(zeroNumber.AppendFormat(nil, 'g', -1)))
+	assert.Equal(
+		t,
+		"%!h(number=1.414213562373095)",
+		string(n.AppendFormat(nil, 'h', 3)))
+}
+
+func TestAppendFormatAllocs(t *testing.T) {
+	n := Sqrt(2)
+	buf := make([]byte, 0, 100)
+	n.AppendFormat(buf, 'f', 50)
+	allocs := testing.AllocsPerRun(100, func() {
+		n.AppendFormat(buf[:0], 'f', 50)
+	})
+	assert.LessOrEqual(t, allocs, 2.0)
+}
+
+func TestWriteDigits(t *testing.T) {
+	var sb strings.Builder
+	n, err := Sqrt(2).WriteDigits(&sb, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.414213562", sb.String())
+	assert.Equal(t, 11, n)
+	assert.Equal(t, "1410000000", writeDigits(SqrtBigInt(big.NewInt(2000000000000000000)), 3))
+	assert.Equal(t, "0.00141", writeDigits(SqrtRat(2, 1000000), 3))
+	assert.Equal(t, "0", writeDigits(zeroNumber, 5))
+	assert.Equal(t, "0", writeDigits(Sqrt(2), 0))
+	finite, _ := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1.25", writeDigits(finite, 10))
+	assert.Panics(t, func() { Sqrt(2).WriteDigits(io.Discard, -1) })
+}
+
+func TestWriteDigitsLarge(t *testing.T) {
+	n := Sqrt(2)
+	expected := fmt.Sprintf("%.20000f", n)
+	assert.Equal(t, expected, writeDigits(n, 20001))
+}
+
+func TestWriteDigitsError(t *testing.T) {
+	w := &failingWriter{limit: 5000}
+	n, err := Sqrt(2).WriteDigits(w, 20000)
+	assert.Equal(t, errWriteFailed, err)
+	assert.Equal(t, w.written, n)
+	assert.LessOrEqual(t, n, 5000)
+}
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct {
+	limit   int
+	written int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.written+len(p) > f.limit {
+		return 0, errWriteFailed
+	}
+	f.written += len(p)
+	return len(p), nil
+}
+
+func writeDigits(n Number, count int) string {
+	var sb strings.Builder
+	n.WriteDigits(&sb, count)
+	return sb.String()
+}
+
+func TestFormatBlocks(t *testing.T) {
+	var sb strings.Builder
+	err := FormatBlocks(&sb, Sqrt(2).WithSignificant(45), 5, 20)
+	assert.NoError(t, err)
+	expected := ` 0  14142 13562 37309 50488
+20  01688 72420 96980 78569
+40  67187
+`
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestFormatBlocksWithStart(t *testing.T) {
+	var sb strings.Builder
+	s := Sqrt(2).WithSignificant(121).FiniteWithStart(1)
+	err := FormatBlocks(&sb, s, 10, 50)
+	assert.NoError(t, err)
+	lines := strings.Split(sb.String(), "\n")
+	assert.Equal(t, "  1  4142135623 7309504880 1688724209 6980785696 7187537694", lines[0])
+	assert.Equal(t, " 51  8073176679 7379907324 7846210703 8850387534 3276415727", lines[1])
+	assert.Equal(t, "101  3501384623 0912297024", lines[2])
+	assert.Equal(t, "", lines[3])
+	assert.Len(t, lines, 4)
+}
+
+func TestFormatBlocksEmpty(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, FormatBlocks(&sb, zeroNumber, 10, 50))
+	assert.Empty(t, sb.String())
+}
+
+func TestFormatBlocksError(t *testing.T) {
+	w := &failingWriter{limit: 100}
+	err := FormatBlocks(w, Sqrt(2).WithSignificant(10000), 10, 50)
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestFormatBlocksPanics(t *testing.T) {
+	s := Sqrt(2).WithSignificant(10)
+	assert.Panics(t, func() { FormatBlocks(io.Discard, s, 0, 50) })
+	assert.Panics(t, func() { FormatBlocks(io.Discard, s, 10, 0) })
+	assert.Panics(t, func() { FormatBlocks(io.Discard, s, 10, 45) })
+}
+
+func TestFormatD(t *testing.T) {
+	assert.Equal(t, "1", fmt.Sprintf("%d", Sqrt(2)))
+	assert.Equal(t, "1414213562", fmt.Sprintf("%d", Sqrt(2000000000000000000)))
+	assert.Equal(t, "1414213562", fmt.Sprintf("%.5d", Sqrt(2000000000000000000)))
+	assert.Equal(t, "0", fmt.Sprintf("%d", SqrtRat(2, 1000000)))
+	assert.Equal(t, "0", fmt.Sprintf("%d", zeroNumber))
+	assert.Equal(t, "  141|", fmt.Sprintf("%5d|", Sqrt(20000)))
+	assert.Equal(t, "141  |", fmt.Sprintf("%-5d|", Sqrt(20000)))
+	assert.Equal(t, "+141", fmt.Sprintf("%+d", Sqrt(20000)))
+	n, _ := NewFiniteNumber([]int{1}, 7)
+	assert.Equal(t, "1000000", fmt.Sprintf("%d", n))
+	assert.Equal(
+		t,
+		"1,000,000",
+		fmt.Sprintf("%d", FormatOptions{IntegerSeparator: ","}.Formatter(
+			Sqrt(1000000000000))))
+	assert.Equal(t, "141", string(Sqrt(20000).AppendFormat(nil, 'd', -1)))
+}
+
+func TestFormatOptionsRounding(t *testing.T) {
+	even := FormatOptions{Rounding: RoundHalfEven}
+	away := FormatOptions{Rounding: RoundHalfAway}
+	sqrt2 := Sqrt(2)
+	assert.Equal(t, "1.414214", fmt.Sprintf("%f", even.Formatter(sqrt2)))
+	assert.Equal(t, "1.414214", fmt.Sprintf("%f", away.Formatter(sqrt2)))
+	assert.Equal(t, "1.414213", fmt.Sprintf("%f", sqrt2))
+	assert.Equal(t, "1.4142", fmt.Sprintf("%.5g", even.Formatter(sqrt2)))
+	assert.Equal(t, "0.14142E+01", fmt.Sprintf("%.5E", away.Formatter(sqrt2)))
+	assert.Equal(t, "1", fmt.Sprintf("%d", even.Formatter(sqrt2)))
+	assert.Equal(t, "2", fmt.Sprintf("%d", even.Formatter(Sqrt(3))))
+	assert.Equal(
+		t,
+		fmt.Sprintf("%.10f", math.Sqrt2),
+		fmt.Sprintf("%.10f", even.Formatter(sqrt2)))
+}
+
+func TestFormatOptionsRoundingCarry(t *testing.T) {
+	even := FormatOptions{Rounding: RoundHalfEven}
+	n, _ := NewFiniteNumber([]int{9, 9, 9, 7}, 3)
+	assert.Equal(t, "1000", fmt.Sprintf("%.0f", even.Formatter(n)))
+	assert.Equal(t, "0.1e+04", fmt.Sprintf("%.3g", even.Formatter(n)))
+	assert.Equal(t, "0.100e+04", fmt.Sprintf("%.3e", even.Formatter(n)))
+	assert.Equal(t, "1000", fmt.Sprintf("%d", even.Formatter(n)))
+	normalized := FormatOptions{
+		Rounding: RoundHalfEven, NormalizedScientific: true}
+	assert.Equal(t, "1.00e+03", fmt.Sprintf("%.2e", normalized.Formatter(n)))
+	assert.Equal(t, "1e+04", fmt.Sprintf("%.0e", normalized.Formatter(Sqrt(99999999))))
+}
+
+func TestFormatOptionsRoundingTies(t *testing.T) {
+	even := FormatOptions{Rounding: RoundHalfEven}
+	away := FormatOptions{Rounding: RoundHalfAway}
+	n, _ := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1.2", fmt.Sprintf("%.1f", even.Formatter(n)))
+	assert.Equal(t, "1.3", fmt.Sprintf("%.1f", away.Formatter(n)))
+	n, _ = NewFiniteNumber([]int{5}, 0)
+	assert.Equal(t, "0", fmt.Sprintf("%.0f", even.Formatter(n)))
+	assert.Equal(t, "1", fmt.Sprintf("%.0f", away.Formatter(n)))
+	assert.Equal(t, "0.0", fmt.Sprintf("%.1f", even.Formatter(SqrtRat(1, 10000))))
+	periodic, _ := NewNumberForTesting([]int{1, 2, 5}, []int{0}, 1)
+	assert.Equal(t, "1.2", fmt.Sprintf("%.1f", even.Formatter(periodic)))
+	assert.Equal(t, "1.3", fmt.Sprintf("%.1f", away.Formatter(periodic)))
+}
+
+func TestFormatOptionsDefaultPrecision(t *testing.T) {
+	options := FormatOptions{DefaultPrecision: 30}
+	sqrt2 := options.Formatter(Sqrt(2))
+	assert.Equal(t, "1.41421356237309504880168872420", fmt.Sprint(sqrt2))
+	assert.Equal(t, "1.41421356237309504880168872420", fmt.Sprintf("%v", sqrt2))
+	assert.Equal(t, "1.414", fmt.Sprintf("%.4g", sqrt2))
+	assert.Equal(t, "1.414213", fmt.Sprintf("%f", sqrt2))
+	options.DefaultPrecision = 3
+	assert.Equal(t, "1.41", fmt.Sprint(options.Formatter(Sqrt(2))))
+	assert.Equal(t, "0.141e+04", fmt.Sprint(options.Formatter(Sqrt(2000000))))
+}
+
+func TestFormatWithDefaultPrecisionMatchesString(t *testing.T) {
+	for _, n := range []Number{
+		Sqrt(2), Sqrt(2000000), InvSqrt(1000000), Sqrt(256), zeroNumber} {
+		assert.Equal(t, n.String(), n.FormatWith(FormatOptions{}))
+		assert.Equal(
+			t,
+			fmt.Sprintf("%.30g", n),
+			n.FormatWith(FormatOptions{DefaultPrecision: 30}))
+		assert.Equal(
+			t,
+			fmt.Sprintf("%.3g", n),
+			n.FormatWith(FormatOptions{DefaultPrecision: 3}))
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	assert.Equal(
+		t,
+		`1.41421\times10^{5}`,
+		Sqrt(20000000000).LaTeX(6))
+	assert.Equal(t, `1.414`, Sqrt(2).LaTeX(4))
+	assert.Equal(t, `1`, Sqrt(2).LaTeX(0))
+	assert.Equal(t, `1.41\times10^{-5}`, SqrtRat(2, 10000000000).LaTeX(3))
+	assert.Equal(t, `1.5\times10^{3}`, CubeRoot(3375000000).LaTeX(6))
+	assert.Equal(t, `0`, zeroNumber.LaTeX(5))
+}
+
+func TestMathML(t *testing.T) {
+	assert.Equal(
+		t,
+		"<math><mn>1.41421</mn><mo>&#xD7;</mo><msup><mn>10</mn><mn>5</mn></msup></math>",
+		Sqrt(20000000000).MathML(6))
+	assert.Equal(t, "<math><mn>1.414</mn></math>", Sqrt(2).MathML(4))
+	assert.Equal(t, "<math><mn>0</mn></math>", zeroNumber.MathML(4))
+}
+
+func TestFormatZeroPadding(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "0000001.4142", fmt.Sprintf("%012.4f", n))
+	assert.Equal(t, fmt.Sprintf("%012.4f", 1.41421), fmt.Sprintf("%012.4f", n))
+	assert.Equal(t, "+000001.4142", fmt.Sprintf("%+012.4f", n))
+	assert.Equal(t, " 000001.4142", fmt.Sprintf("% 012.4f", n))
+	assert.Equal(t, "1.4142      |", fmt.Sprintf("%-012.4f|", n))
+	assert.Equal(t, "1.4142", fmt.Sprintf("%05.4f", n))
+	assert.Equal(t, "000.141e+01", fmt.Sprintf("%011.3e", n))
+	assert.Equal(t, "00141", fmt.Sprintf("%05d", Sqrt(20000)))
+}
+
+func TestFprint(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, Fprint(&sb, Sqrt(2), FormatOptions{}))
+	assert.Equal(t, Sqrt(2).String(), sb.String())
+	sb.Reset()
+	options := FormatOptions{
+		DefaultPrecision: 10,
+		IntegerSeparator: ",",
+		Rounding:         RoundHalfEven,
+	}
+	assert.NoError(t, Fprint(&sb, Sqrt(2000000), options))
+	assert.Equal(t, "1,414.213562", sb.String())
+	sb.Reset()
+	assert.NoError(t, Fprint(&sb, zeroNumber, options))
+	assert.Equal(t, "0", sb.String())
+}
+
+func TestFprintError(t *testing.T) {
+	w := &failingWriter{limit: 100}
+	err := Fprint(w, Sqrt(2), FormatOptions{DefaultPrecision: 10000})
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestFormatWith(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, n.String(), n.FormatWith(FormatOptions{}))
+	assert.Equal(t, "1.4142", n.FormatWith(FormatOptions{SigDigits: 5}))
+	assert.Equal(
+		t,
+		"0.14142E+01",
+		n.FormatWith(
+			FormatOptions{SigDigits: 5, Scientific: true, Capital: true}))
+	assert.Equal(
+		t,
+		"1.4142e+00",
+		n.FormatWith(FormatOptions{
+			SigDigits:            5,
+			Scientific:           true,
+			NormalizedScientific: true,
+		}))
+	assert.Equal(
+		t,
+		"4.0000",
+		Sqrt(16).FormatWith(
+			FormatOptions{SigDigits: 5, ExactDigitCount: true}))
+	assert.Equal(
+		t,
+		"0.1e+05",
+		Sqrt(99999999).FormatWith(
+			FormatOptions{SigDigits: 3, Rounding: RoundHalfAway}))
+	assert.Equal(
+		t,
+		"9999.99",
+		Sqrt(99999999).FormatWith(FormatOptions{SigDigits: 6}))
+	fn := n.WithSignificant(8)
+	assert.Equal(t, "1.414214", fn.FormatWith(FormatOptions{
+		SigDigits: 7, Rounding: RoundHalfEven}))
+}
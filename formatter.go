@@ -3,14 +3,57 @@ package sqrt
 import (
 	"bufio"
 	"io"
+	"unicode/utf8"
 )
 
+// digitWriter is what formatter writes to. *bufio.Writer,
+// *strings.Builder, and *appendWriter are digitWriters.
+type digitWriter interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+	WriteRune(r rune) (int, error)
+}
+
+// appendWriter is a digitWriter that appends to a byte slice.
+type appendWriter struct {
+	buf []byte
+}
+
+func (a *appendWriter) Write(p []byte) (int, error) {
+	a.buf = append(a.buf, p...)
+	return len(p), nil
+}
+
+func (a *appendWriter) WriteByte(c byte) error {
+	a.buf = append(a.buf, c)
+	return nil
+}
+
+func (a *appendWriter) WriteString(s string) (int, error) {
+	a.buf = append(a.buf, s...)
+	return len(s), nil
+}
+
+func (a *appendWriter) WriteRune(r rune) (int, error) {
+	n := len(a.buf)
+	a.buf = utf8.AppendRune(a.buf, r)
+	return len(a.buf) - n, nil
+}
+
 type formatter struct {
-	writer          *bufio.Writer
+	writer          digitWriter
+	buffered        *bufio.Writer
 	sigDigits       int // invariant sigDigits >= exponent
 	exponent        int
 	exactDigitCount bool
 	index           int
+	maskFrom        int
+	maskChar        rune
+	digitChars      string
+	intSep          string
+	fracGroup       int
+	fracSep         string
 }
 
 func newFormatter(
@@ -18,14 +61,43 @@ func newFormatter(
 	if sigDigits < exponent {
 		panic("sigDigits must be >= exponent")
 	}
+	writer, ok := w.(digitWriter)
+	var buffered *bufio.Writer
+	if !ok {
+		buffered = bufio.NewWriter(w)
+		writer = buffered
+	}
 	return &formatter{
-		writer:          bufio.NewWriter(w),
+		writer:          writer,
+		buffered:        buffered,
 		sigDigits:       sigDigits,
 		exponent:        exponent,
 		exactDigitCount: exactDigitCount,
+		digitChars:      kLowerDigitChars,
 	}
 }
 
+// SetMask makes f print maskChar in place of each significant digit at
+// position maskFrom and beyond.
+func (f *formatter) SetMask(maskFrom int, maskChar rune) {
+	f.maskFrom = maskFrom
+	f.maskChar = maskChar
+}
+
+// SetDigitChars makes f print digitChars[d] for each digit d.
+func (f *formatter) SetDigitChars(digitChars string) {
+	f.digitChars = digitChars
+}
+
+// SetGrouping makes f print intSep between each group of three digits
+// before the decimal point. If fracGroup is positive, f prints fracSep
+// between each group of fracGroup digits after the decimal point.
+func (f *formatter) SetGrouping(intSep string, fracGroup int, fracSep string) {
+	f.intSep = intSep
+	f.fracGroup = fracGroup
+	f.fracSep = fracSep
+}
+
 func (f *formatter) CanConsume() bool {
 	return f.index < f.sigDigits
 }
@@ -53,7 +125,9 @@ func (f *formatter) Finish() {
 		}
 		f.addLeadingZeros(count)
 	}
-	f.writer.Flush()
+	if f.buffered != nil {
+		f.buffered.Flush()
+	}
 }
 
 func (f *formatter) add(digit int) {
@@ -63,7 +137,18 @@ func (f *formatter) add(digit int) {
 	if f.index == f.exponent {
 		f.writer.WriteByte('.')
 	}
-	f.writer.WriteByte('0' + byte(digit))
+	if f.index < f.exponent {
+		if f.index > 0 && (f.exponent-f.index)%3 == 0 {
+			f.writer.WriteString(f.intSep)
+		}
+	} else {
+		f.addFracSep(f.index - f.exponent)
+	}
+	if f.maskChar != 0 && f.index >= f.maskFrom {
+		f.writer.WriteRune(f.maskChar)
+	} else {
+		f.writer.WriteByte(f.digitChars[digit])
+	}
 	f.index++
 }
 
@@ -74,6 +159,15 @@ func (f *formatter) addLeadingZeros(count int) {
 	}
 	f.writer.WriteByte('.')
 	for i := 0; i < count; i++ {
+		f.addFracSep(i)
 		f.writer.WriteByte('0')
 	}
 }
+
+// addFracSep adds fracSep if the digit at the given 0 based position after
+// the decimal point starts a new group.
+func (f *formatter) addFracSep(posit int) {
+	if f.fracGroup > 0 && posit > 0 && posit%f.fracGroup == 0 {
+		f.writer.WriteString(f.fracSep)
+	}
+}
@@ -20,6 +20,16 @@ type Generator interface {
 	Generate() (digits func() int, exp int)
 }
 
+// SequenceGenerator returns a Generator that generates the digits of s
+// as the mantissa and exp as the exponent. Passing the returned Generator
+// to NewNumber turns s back into a Number. The first digit of s becomes
+// the first digit of the mantissa regardless of its position in s, so if
+// s is empty or its first digit is 0, NewNumber returns zero. The
+// returned Generator reads the digits of s only as needed.
+func SequenceGenerator(s Sequence, exp int) Generator {
+	return &sequenceGenerator{s: s, exp: exp}
+}
+
 func newNRootGenerator(
 	num, denom *big.Int, newManager func() rootManager) Generator {
 	result := &nrootGenerator{newManager: newManager}
@@ -61,6 +71,58 @@ func (g *repeatingGenerator) Generate() (func() int, int) {
 	return gen, g.exp
 }
 
+type sequenceGenerator struct {
+	s   Sequence
+	exp int
+}
+
+func (g *sequenceGenerator) Generate() (func() int, int) {
+	return concatDigits([]Sequence{g.s}), g.exp
+}
+
+func newNewtonSqrtGenerator(num, denom *big.Int) Generator {
+	result := &newtonSqrtGenerator{}
+	result.num.Set(num)
+	result.denom.Set(denom)
+	return result
+}
+
+type newtonSqrtGenerator struct {
+	num   big.Int
+	denom big.Int
+}
+
+func (g *newtonSqrtGenerator) Generate() (func() int, int) {
+	num, denom, exp := normalizeRational(&g.num, &g.denom, oneHundred)
+	return computeNewtonSqrtDigits(num, denom), exp
+}
+
+// newRootGenerator returns a Generator for the nth root of num / denom.
+func newRootGenerator(num, denom *big.Int, n int) Generator {
+	if n == 2 {
+		return newSqrtGenerator(num, denom)
+	}
+	return newNRootGenerator(num, denom, rootManagerFactory(n))
+}
+
+func newSqrtGenerator(num, denom *big.Int) Generator {
+	result := &sqrtGenerator{}
+	result.num.Set(num)
+	result.denom.Set(denom)
+	return result
+}
+
+// sqrtGenerator generates square roots in blocks of kSqrtBlockDigits
+// digits.
+type sqrtGenerator struct {
+	num   big.Int
+	denom big.Int
+}
+
+func (g *sqrtGenerator) Generate() (func() int, int) {
+	return computeSqrtDigitsInBlocks(&g.num, &g.denom)
+}
+
 type nrootGenerator struct {
 	num        big.Int
 	denom      big.Int
@@ -73,3 +135,63 @@ func (g *nrootGenerator) Generate() (func() int, int) {
 		&g.num, &g.denom, manager.Base(new(big.Int)))
 	return computeRootDigits(groups, manager), exp
 }
+
+const (
+	kIntervalInitialPrecision = 16
+)
+
+// newIntervalGenerator returns a Generator for a positive real number x.
+// bounds(k) must return lo and hi such that lo <= x*10^k <= hi, and
+// the relative width of the interval must shrink toward zero as k grows.
+// If x is an exact power of 10, or if x has a finite number of digits,
+// the returned Generator never finishes computing the exponent or the
+// final digit respectively.
+func newIntervalGenerator(bounds func(k int) (lo, hi *big.Int)) Generator {
+	return &intervalGenerator{bounds: bounds}
+}
+
+type intervalGenerator struct {
+	bounds func(k int) (lo, hi *big.Int)
+}
+
+func (g *intervalGenerator) Generate() (func() int, int) {
+	k := kIntervalInitialPrecision
+	for {
+		lo, hi := g.bounds(k)
+		if lo.Sign() > 0 {
+			loStr, hiStr := lo.String(), hi.String()
+			if len(loStr) == len(hiStr) {
+				return g.digits(k, commonPrefix(loStr, hiStr)), len(loStr) - k
+			}
+		}
+		k *= 2
+	}
+}
+
+func (g *intervalGenerator) digits(k int, known string) func() int {
+	index := 0
+	return func() int {
+		for index >= len(known) {
+			k *= 2
+			lo, hi := g.bounds(k)
+			loStr, hiStr := lo.String(), hi.String()
+			if len(loStr) != len(hiStr) {
+				continue
+			}
+			if prefix := commonPrefix(loStr, hiStr); len(prefix) > len(known) {
+				known = prefix
+			}
+		}
+		result := int(known[index] - '0')
+		index++
+		return result
+	}
+}
+
+func commonPrefix(x, y string) string {
+	i := 0
+	for i < len(x) && i < len(y) && x[i] == y[i] {
+		i++
+	}
+	return x[:i]
+}
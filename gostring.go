@@ -0,0 +1,86 @@
+package sqrt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MustFinite works like NewFiniteNumber except that it panics instead of
+// returning an error. GoString uses MustFinite to show FiniteNumbers.
+func MustFinite(fixed []int, exponent int) *FiniteNumber {
+	result, err := NewFiniteNumber(fixed, exponent)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustNumber works like NewNumberForTesting except that it panics instead
+// of returning an error. GoString uses MustNumber to show periodic
+// Numbers.
+func MustNumber(fixed, repeating []int, exp int) Number {
+	result, err := NewNumberForTesting(fixed, repeating, exp)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// GoString returns a Go expression that evaluates to this FiniteNumber for
+// the %#v verb, for example sqrt.MustFinite([]int{1, 4, 1, 4}, 1).
+func (n *FiniteNumber) GoString() string {
+	return goStringFinite(&n.numberPart, false)
+}
+
+// GoString returns a Go expression for the %#v verb. If this Number has a
+// finite number of digits or is periodic, the expression evaluates to
+// this Number, for example sqrt.MustNumber([]int{3}, []int{6}, 0).
+// Otherwise, no Go expression evaluates to this Number, so GoString shows
+// the first 16 digits as a FiniteNumber with "..." after the last digit,
+// for example sqrt.MustFinite([]int{1, 4, 1, 4, ...}, 1). Because of the
+// "...", that output does not compile, so it cannot be mistaken for an
+// exact value.
+func (n *number) GoString() string {
+	switch {
+	case n.cycle != nil:
+		digits := n.withEnd(n.cycle.start + n.cycle.length)
+		return fmt.Sprintf(
+			"sqrt.MustNumber(%s, %s, %d)",
+			goDigits(digits.WithEnd(n.cycle.start)),
+			goDigits(digits.FiniteWithStart(n.cycle.start)),
+			n.exponent)
+	case n.IsTerminating():
+		return goStringFinite(&n.numberPart, false)
+	default:
+		truncated := n.withEnd(gPrecision)
+		return goStringFinite(&truncated.numberPart, true)
+	}
+}
+
+// goStringFinite returns n as a MustFinite expression. If more is true,
+// "..." follows the digits of n to show that there are more.
+func goStringFinite(n *numberPart, more bool) string {
+	digits := goDigits(&FiniteNumber{*n})
+	if more {
+		digits = strings.TrimSuffix(digits, "}") + ", ...}"
+	}
+	return fmt.Sprintf("sqrt.MustFinite(%s, %d)", digits, n.exponent)
+}
+
+// goDigits returns the digits of s as a Go []int literal.
+func goDigits(s FiniteSequence) string {
+	var sb strings.Builder
+	for digit := range s.Values() {
+		if sb.Len() == 0 {
+			sb.WriteString("[]int{")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('0' + byte(digit))
+	}
+	if sb.Len() == 0 {
+		return "nil"
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
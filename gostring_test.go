@@ -0,0 +1,48 @@
+package sqrt
+
+import (
+	"fmt"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoStringFinite(t *testing.T) {
+	assert.Equal(
+		t,
+		"sqrt.MustFinite([]int{1, 4, 1, 4}, 1)",
+		fmt.Sprintf("%#v", Sqrt(2).WithSignificant(4)))
+	assert.Equal(t, "sqrt.MustFinite(nil, 0)", fmt.Sprintf("%#v", zeroNumber))
+	n := MustFinite([]int{2, 5}, -3)
+	assert.Equal(t, "sqrt.MustFinite([]int{2, 5}, -3)", n.GoString())
+}
+
+func TestGoStringNumber(t *testing.T) {
+	assert.Equal(
+		t,
+		"sqrt.MustFinite([]int{1, 4, 1, 4, 2, 1, 3, 5, 6, 2, 3, 7, 3, 0, 9, 5, ...}, 1)",
+		fmt.Sprintf("%#v", Sqrt(2)))
+
+	// Inexact output must not compile.
+	_, err := parser.ParseExpr(Sqrt(2).(fmt.GoStringer).GoString())
+	assert.Error(t, err)
+	_, err = parser.ParseExpr(Sqrt(2).WithSignificant(4).GoString())
+	assert.NoError(t, err)
+	n := Sqrt(256)
+	n.At(5)
+	assert.Equal(t, "sqrt.MustFinite([]int{1, 6}, 2)", fmt.Sprintf("%#v", n))
+	assert.Equal(
+		t,
+		"sqrt.MustNumber(nil, []int{3}, 0)",
+		fmt.Sprintf("%#v", MustNumber(nil, []int{3}, 0)))
+	assert.Equal(
+		t,
+		"sqrt.MustNumber([]int{1, 2}, []int{3, 4}, -2)",
+		fmt.Sprintf("%#v", MustNumber([]int{1, 2}, []int{3, 4}, -2)))
+}
+
+func TestMustPanics(t *testing.T) {
+	assert.Panics(t, func() { MustFinite([]int{0, 1}, 0) })
+	assert.Panics(t, func() { MustNumber([]int{1}, []int{10}, 0) })
+}
@@ -0,0 +1,45 @@
+package sqrt
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SqrtSigned returns the square root of radican. If radican is negative,
+// SqrtSigned returns the square root of -radican and true to indicate that
+// the actual result is imaginary. Use Imaginary to print such results.
+func SqrtSigned(radican int64) (n Number, imaginary bool) {
+	if radican < 0 {
+		return SqrtBigInt(new(big.Int).Neg(big.NewInt(radican))), true
+	}
+	return Sqrt(radican), false
+}
+
+// ImaginaryNumber is a Number multiplied by i.
+type ImaginaryNumber struct {
+	magnitude Number
+}
+
+// Imaginary returns n multiplied by i.
+func Imaginary(n Number) ImaginaryNumber {
+	return ImaginaryNumber{magnitude: n}
+}
+
+// Magnitude returns the Number that this ImaginaryNumber multiplies by i.
+func (n ImaginaryNumber) Magnitude() Number {
+	return n.magnitude
+}
+
+// Format prints this ImaginaryNumber the same way its Magnitude prints
+// followed by a trailing "i". Width applies to the whole field including
+// the "i".
+func (n ImaginaryNumber) Format(state fmt.State, verb rune) {
+	n.magnitude.impl().formatWithOptions(
+		state, verb, formatOptions{suffix: "i", source: n.magnitude})
+}
+
+// String returns the decimal representation of this ImaginaryNumber using
+// %g followed by a trailing "i".
+func (n ImaginaryNumber) String() string {
+	return n.magnitude.String() + "i"
+}
@@ -0,0 +1,39 @@
+package sqrt
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqrtSigned(t *testing.T) {
+	n, imaginary := SqrtSigned(2)
+	assert.False(t, imaginary)
+	assert.Equal(t, Sqrt(2).String(), n.String())
+	n, imaginary = SqrtSigned(-4)
+	assert.True(t, imaginary)
+	assert.Equal(t, "2", n.String())
+	n, imaginary = SqrtSigned(0)
+	assert.False(t, imaginary)
+	assert.True(t, n.IsZero())
+	n, imaginary = SqrtSigned(math.MinInt64)
+	assert.True(t, imaginary)
+	assert.Equal(t, "3037000499.97604", fmt.Sprintf("%.5f", n))
+}
+
+func TestImaginary(t *testing.T) {
+	n, _ := SqrtSigned(-2)
+	i := Imaginary(n)
+	assert.Same(t, n, i.Magnitude())
+	assert.Equal(t, "1.414213562373095i", i.String())
+	assert.Equal(t, "1.414i", fmt.Sprintf("%.4g", i))
+	assert.Equal(t, "1.41421i", fmt.Sprintf("%.5f", i))
+	assert.Equal(t, "0.141E+01i", fmt.Sprintf("%.3E", i))
+	assert.Equal(t, "  1.414i", fmt.Sprintf("%8.4g", i))
+	assert.Equal(t, "1.414i  |", fmt.Sprintf("%-8.4g|", i))
+	assert.Equal(t, "1i", fmt.Sprintf("%d", i))
+	assert.Equal(t, "%!h(number=1.414213562373095i)", fmt.Sprintf("%h", i))
+	assert.Equal(t, "0i", Imaginary(zeroNumber).String())
+}
@@ -0,0 +1,179 @@
+package sqrt
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. MarshalText returns the
+// same digits that Exact returns, so no precision is lost.
+func (n *FiniteNumber) MarshalText() ([]byte, error) {
+	return []byte(n.Exact()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. UnmarshalText accepts
+// what MarshalText returns: a non-negative decimal number with an optional
+// exponent such as "1.414", "0.001", or "0.1414e+08".
+func (n *FiniteNumber) UnmarshalText(text []byte) error {
+	result, err := parseFiniteNumber(string(text))
+	if err != nil {
+		return err
+	}
+	n.numberPart = result.numberPart
+	return nil
+}
+
+// kDigitPadding fills the low half of the last byte when there is an odd
+// number of packed digits.
+const kDigitPadding = 0xf
+
+var errInvalidBinary = errors.New("sqrt: invalid binary FiniteNumber")
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the
+// exponent as a varint followed by all the digits packed as PackedDigits
+// packs them, so it is about half the size of MarshalText for numbers
+// with many digits.
+func (n *FiniteNumber) MarshalBinary() ([]byte, error) {
+	result := binary.AppendVarint(nil, int64(n.exponent))
+	return appendPackedDigits(result, n.Values(), math.MaxInt), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. UnmarshalBinary
+// accepts what MarshalBinary returns.
+func (n *FiniteNumber) UnmarshalBinary(data []byte) error {
+	exponent, size := binary.Varint(data)
+	if size <= 0 {
+		return errInvalidBinary
+	}
+	data = data[size:]
+	if len(data) == 0 {
+		n.numberPart = numberPart{}
+		return nil
+	}
+	fixed := make([]int, 0, 2*len(data))
+	for i, b := range data {
+		high, low := b>>4, b&0xf
+		last := i == len(data)-1
+		if high > 9 || (low > 9 && (low != kDigitPadding || !last)) {
+			return errInvalidBinary
+		}
+		fixed = append(fixed, int(high))
+		if low != kDigitPadding {
+			fixed = append(fixed, int(low))
+		}
+	}
+	if fixed[0] == 0 {
+		return errInvalidBinary
+	}
+
+	// Unlike text, keep trailing zeros so that the digits round trip.
+	n.numberPart = newnumberPart(
+		newRepeatingGenerator(fixed, nil, int(exponent)).Generate())
+	return nil
+}
+
+// Import returns the FiniteNumber with the given digits and exponent.
+// Each digit must be between 0 and 9, and the first digit must not be 0.
+// If digits is empty, Import returns 0. Import reverses Number.Export.
+func Import(digits []byte, exp int) (*FiniteNumber, error) {
+	if len(digits) == 0 {
+		return &FiniteNumber{}, nil
+	}
+	if digits[0] == 0 {
+		return nil, errors.New("sqrt: leading zeros not allowed in digits")
+	}
+	fixed := make([]int, len(digits))
+	for i, digit := range digits {
+		if digit > 9 {
+			return nil, errors.New("sqrt: digits must be between 0 and 9")
+		}
+		fixed[i] = int(digit)
+	}
+	generator := newRepeatingGenerator(fixed, nil, exp)
+	return newFiniteNumber(generator.Generate()), nil
+}
+
+func export(n Number, maxDigits int) (
+	digits []byte, exp int, exhausted bool) {
+	for digit := range n.WithSignificant(maxDigits).Values() {
+		digits = append(digits, byte(digit))
+	}
+	return digits, n.Exponent(), n.At(maxDigits) == -1
+}
+
+// Decimal is the coefficient digits and exponent of a Number in the form
+// that localized formatting pipelines such as golang.org/x/text/number
+// consume. The value is 0.Digits * 10^Exp.
+type Decimal struct {
+
+	// Digits holds the digits of the coefficient as values between 0 and
+	// 9 without leading zeros. Digits is empty for 0.
+	Digits []byte
+
+	// Exp is the exponent.
+	Exp int32
+
+	// Truncated is true if the Number has more digits than Digits holds.
+	Truncated bool
+}
+
+func decimal(n Number, maxDigits int) (Decimal, error) {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	if n.Exponent() < math.MinInt32 || n.Exponent() > math.MaxInt32 {
+		return Decimal{}, ErrExponentRange
+	}
+	digits, exp, exhausted := export(n, maxDigits)
+	return Decimal{
+		Digits: digits, Exp: int32(exp), Truncated: !exhausted}, nil
+}
+
+// appendPackedDigits appends the first max digits in values to dst packed
+// the way Sequence.PackedDigits describes and returns the extended slice.
+func appendPackedDigits(dst []byte, values iter.Seq[int], max int) []byte {
+	if max < 0 {
+		panic("max must be non-negative")
+	}
+	count := 0
+	for digit := range values {
+		if count == max {
+			break
+		}
+		if count%2 == 0 {
+			dst = append(dst, byte(digit)<<4|kDigitPadding)
+		} else {
+			dst[len(dst)-1] = dst[len(dst)-1]&0xf0 | byte(digit)
+		}
+		count++
+	}
+	return dst
+}
+
+func parseFiniteNumber(text string) (*FiniteNumber, error) {
+	mantissa, exponent := text, 0
+	if i := strings.IndexAny(text, "eE"); i != -1 {
+		var err error
+		mantissa = text[:i]
+		exponent, err = strconv.Atoi(text[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("sqrt: invalid number %q", text)
+		}
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, fmt.Errorf("sqrt: invalid number %q", text)
+	}
+	exponent += len(intPart)
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+		exponent--
+	}
+	return finiteNumberFromDigits(digits, exponent), nil
+}
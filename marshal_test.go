@@ -0,0 +1,225 @@
+package sqrt
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.TextMarshaler     = (*FiniteNumber)(nil)
+	_ encoding.TextUnmarshaler   = (*FiniteNumber)(nil)
+	_ encoding.BinaryMarshaler   = (*FiniteNumber)(nil)
+	_ encoding.BinaryUnmarshaler = (*FiniteNumber)(nil)
+)
+
+func TestMarshalTextRoundTrip(t *testing.T) {
+	n := Sqrt(2).WithSignificant(20)
+	assertTextRoundTrip(t, Sqrt(2).WithSignificant(1000))
+	assertTextRoundTrip(t, n.withExponent(-10).(*FiniteNumber))
+	assertTextRoundTrip(t, n.withExponent(500).(*FiniteNumber))
+	assertTextRoundTrip(t, Sqrt(1000000).WithSignificant(10))
+	assertTextRoundTrip(t, zeroNumber)
+}
+
+func TestMarshalText(t *testing.T) {
+	text, err := Sqrt(2).WithSignificant(5).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4142", string(text))
+}
+
+func TestUnmarshalText(t *testing.T) {
+	assertUnmarshalText(t, "0.25", []int{2, 5}, 0)
+	assertUnmarshalText(t, "00125.000", []int{1, 2, 5}, 3)
+	assertUnmarshalText(t, "0.00125", []int{1, 2, 5}, -2)
+	assertUnmarshalText(t, "0.125E+09", []int{1, 2, 5}, 9)
+	assertUnmarshalText(t, "12.5e-3", []int{1, 2, 5}, -1)
+	assertUnmarshalText(t, ".5", []int{5}, 0)
+	assertUnmarshalText(t, "0.000", nil, 0)
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	for _, text := range []string{"", ".", "-1", "1.2.3", "1e", "1e+x", "abc"} {
+		var n FiniteNumber
+		assert.Error(t, n.UnmarshalText([]byte(text)), text)
+	}
+}
+
+func TestUnmarshalTextIntoZero(t *testing.T) {
+	var value struct{ X *FiniteNumber }
+	value.X = Sqrt(0).(*FiniteNumber)
+	assert.NoError(t, json.Unmarshal([]byte(`{"X":"1.5"}`), &value))
+	assert.Equal(t, "1.5", value.X.String())
+	assert.True(t, Sqrt(0).IsZero())
+	assert.Equal(t, "0", Sqrt(0).String())
+	n, _ := NewFiniteNumber(nil, 0)
+	assert.True(t, n.IsZero())
+	n, _ = Import(nil, 0)
+	assert.True(t, n.IsZero())
+	assert.True(t, zeroNumber.IsZero())
+}
+
+func TestUnmarshalBinaryIntoSharedValues(t *testing.T) {
+	data, err := MustFinite([]int{1, 5}, 1).MarshalBinary()
+	assert.NoError(t, err)
+	zero := Sqrt(0).(*FiniteNumber)
+	assert.NoError(t, zero.UnmarshalBinary(data))
+	assert.Equal(t, "1.5", zero.String())
+	assert.True(t, Sqrt(0).IsZero())
+	one := Exp(Sqrt(0)).(*FiniteNumber)
+	assert.NoError(t, one.UnmarshalBinary(data))
+	assert.Equal(t, "1.5", one.String())
+	assert.Equal(t, "1", Exp(Sqrt(0)).String())
+}
+
+func assertTextRoundTrip(t *testing.T, n *FiniteNumber) {
+	t.Helper()
+	text, err := n.MarshalText()
+	assert.NoError(t, err)
+	var actual FiniteNumber
+	assert.NoError(t, actual.UnmarshalText(text))
+	assert.Equal(t, n.Exponent(), actual.Exponent())
+	assert.Equal(t, AsString(n), AsString(&actual))
+}
+
+func assertUnmarshalText(
+	t *testing.T, text string, fixed []int, exponent int) {
+	t.Helper()
+	expected, err := NewFiniteNumber(fixed, exponent)
+	assert.NoError(t, err)
+	var actual FiniteNumber
+	assert.NoError(t, actual.UnmarshalText([]byte(text)))
+	assert.Equal(t, expected.Exponent(), actual.Exponent())
+	assert.Equal(t, AsString(expected), AsString(&actual))
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	n := Sqrt(2).WithSignificant(21)
+	assertBinaryRoundTrip(t, Sqrt(2).WithSignificant(1000))
+	assertBinaryRoundTrip(t, n)
+	assertBinaryRoundTrip(t, n.withExponent(-10).(*FiniteNumber))
+	assertBinaryRoundTrip(t, n.withExponent(500000).(*FiniteNumber))
+	assertBinaryRoundTrip(t, zeroNumber)
+}
+
+func TestMarshalBinary(t *testing.T) {
+	data, err := Sqrt(2).WithSignificant(5).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x2, 0x14, 0x14, 0x2f}, data)
+	small := Sqrt(2).WithSignificant(4).withExponent(-1).(*FiniteNumber)
+	data, err = small.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x1, 0x14, 0x14}, data)
+	data, err = zeroNumber.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0}, data)
+}
+
+func TestUnmarshalBinaryError(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{0x80},
+		{0x2, 0x1a},
+		{0x2, 0xa1},
+		{0x2, 0x1f, 0x12},
+		{0x2, 0x01},
+	} {
+		var n FiniteNumber
+		assert.Error(t, n.UnmarshalBinary(data), "%x", data)
+	}
+}
+
+func assertBinaryRoundTrip(t *testing.T, n *FiniteNumber) {
+	t.Helper()
+	data, err := n.MarshalBinary()
+	assert.NoError(t, err)
+	var actual FiniteNumber
+	assert.NoError(t, actual.UnmarshalBinary(data))
+	assert.Equal(t, n.Exponent(), actual.Exponent())
+	assert.Equal(t, AsString(n), AsString(&actual))
+}
+
+func TestExport(t *testing.T) {
+	digits, exp, exhausted := Sqrt(200).Export(5)
+	assert.Equal(t, []byte{1, 4, 1, 4, 2}, digits)
+	assert.Equal(t, 2, exp)
+	assert.False(t, exhausted)
+	digits, exp, exhausted = Sqrt(256).Export(5)
+	assert.Equal(t, []byte{1, 6}, digits)
+	assert.Equal(t, 2, exp)
+	assert.True(t, exhausted)
+	digits, _, exhausted = Sqrt(2).WithSignificant(5).Export(5)
+	assert.Len(t, digits, 5)
+	assert.True(t, exhausted)
+	digits, _, exhausted = zeroNumber.Export(5)
+	assert.Empty(t, digits)
+	assert.True(t, exhausted)
+	assert.Panics(t, func() { Sqrt(2).Export(-1) })
+}
+
+func TestImport(t *testing.T) {
+	digits, exp, _ := Sqrt(2).Export(1000)
+	n, err := Import(digits, exp)
+	assert.NoError(t, err)
+	assert.Equal(t, AsString(Sqrt(2).WithSignificant(1000)), AsString(n))
+	assert.Equal(t, 1, n.Exponent())
+	n, err = Import([]byte{2, 5, 0}, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0250", n.Exact())
+	n, err = Import(nil, 7)
+	assert.NoError(t, err)
+	assert.True(t, n.IsZero())
+	_, err = Import([]byte{0, 1}, 0)
+	assert.Error(t, err)
+	_, err = Import([]byte{1, 10}, 0)
+	assert.Error(t, err)
+}
+
+func TestDecimal(t *testing.T) {
+	assertDecimal(
+		t,
+		Decimal{Digits: []byte{1, 4, 1, 4, 2}, Exp: 2, Truncated: true},
+		Sqrt(200),
+		5)
+	assertDecimal(t, Decimal{Digits: []byte{1, 6}, Exp: 2}, Sqrt(256), 5)
+	assertDecimal(
+		t,
+		Decimal{Digits: []byte{1, 4}, Exp: 1},
+		Sqrt(2).WithSignificant(2),
+		5)
+	assertDecimal(t, Decimal{}, zeroNumber, 5)
+	assert.Panics(t, func() { Sqrt(2).Decimal(-1) })
+	assertDecimal(
+		t,
+		Decimal{Digits: []byte{1}, Exp: math.MaxInt32},
+		MustFinite([]int{1}, math.MaxInt32),
+		5)
+	_, err := MustFinite([]int{1}, math.MaxInt32+1).Decimal(5)
+	assert.ErrorIs(t, err, ErrExponentRange)
+	_, err = MustFinite([]int{1}, math.MinInt32-1).Decimal(5)
+	assert.ErrorIs(t, err, ErrExponentRange)
+}
+
+func assertDecimal(t *testing.T, expected Decimal, n Number, maxDigits int) {
+	t.Helper()
+	actual, err := n.Decimal(maxDigits)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPackedDigits(t *testing.T) {
+	assert.Equal(t, []byte{0x14, 0x14, 0x2f}, Sqrt(2).PackedDigits(5))
+	assert.Equal(t, []byte{0x14, 0x14}, Sqrt(2).PackedDigits(4))
+	assert.Equal(t, []byte{0x42, 0x13}, Sqrt(2).WithStart(3).PackedDigits(4))
+	assert.Equal(
+		t, []byte{0x41, 0x4f}, Sqrt(2).WithStart(1).WithEnd(4).PackedDigits(10))
+	assert.Equal(
+		t, []byte{0x14, 0x14}, Sqrt(2).WithSignificant(4).PackedDigits(100))
+	assert.Empty(t, Sqrt(2).PackedDigits(0))
+	assert.Empty(t, zeroNumber.PackedDigits(10))
+	assert.Len(t, Sqrt(2).PackedDigits(10001), 5001)
+	assert.Panics(t, func() { Sqrt(2).PackedDigits(-1) })
+}
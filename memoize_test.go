@@ -55,3 +55,82 @@ func TestMemoizeAt(t *testing.T) {
 		assert.Equal(t, expected, actual[i])
 	}
 }
+
+func TestDigitDataBlocks(t *testing.T) {
+	var data digitData
+	size := 2*kDigitBlockSize + 5
+	for i := range size {
+		data = data.appendDigit(int8(i % 7))
+	}
+	assert.Equal(t, size, data.Len())
+	assert.Len(t, data.full, 2)
+	for _, i := range []int{0, kDigitBlockSize - 1, kDigitBlockSize, size - 1} {
+		assert.Equal(t, int8(i%7), data.At(i))
+	}
+
+	// Within one block
+	start := kDigitBlockSize + 3
+	span := data.Span(start, start+3)
+	assert.Equal(
+		t,
+		[]int8{int8(start % 7), int8((start + 1) % 7), int8((start + 2) % 7)},
+		span)
+	assert.Equal(t, 3, cap(span))
+
+	// Across blocks
+	start = kDigitBlockSize - 2
+	span = data.Span(start, start+4)
+	assert.Len(t, span, 4)
+	for i, digit := range span {
+		assert.Equal(t, int8((start+i)%7), digit)
+	}
+	dst := make([]int8, 10)
+	assert.Equal(t, 7, data.CopyTo(dst, size-7))
+	assert.Equal(t, int8((size-1)%7), dst[6])
+
+	shorter := data.WithMaxLength(kDigitBlockSize + 1)
+	assert.Equal(t, kDigitBlockSize+1, shorter.Len())
+	assert.Len(t, shorter.Span(kDigitBlockSize, size), 1)
+
+	// Earlier views never see later digits.
+	more := data.appendDigit(3)
+	assert.Equal(t, size, data.Len())
+	assert.Equal(t, size+1, more.Len())
+	assert.Equal(t, int8(3), more.At(size))
+}
+
+func TestMemoizerAcrossBlocks(t *testing.T) {
+	size := kDigitBlockSize + 1000
+	i := 0
+	m := newdigitMemoizer(func() int {
+		if i == size {
+			return -1
+		}
+		i++
+		return (i - 1) % 10
+	})
+	var count int
+	m.Scan(kDigitBlockSize-500, size+10, func(index, value int) bool {
+		assert.Equal(t, index%10, value)
+		count++
+		return true
+	})
+	assert.Equal(t, 1500, count)
+	assert.Equal(t, size, m.NumComputed())
+	assert.True(t, m.Done())
+	count = 0
+	m.ReverseScan(kDigitBlockSize-500, size, func(index, value int) bool {
+		assert.Equal(t, index%10, value)
+		count++
+		return true
+	})
+	assert.Equal(t, 1500, count)
+	mant := mantissa{digits: m, maxDigits: size}
+	var total int
+	mant.ScanChunks(0, 300000, func(index int, chunk []int8) bool {
+		assert.Equal(t, int8(index%10), chunk[0])
+		total += len(chunk)
+		return true
+	})
+	assert.Equal(t, size, total)
+}
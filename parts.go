@@ -6,7 +6,10 @@ import (
 	"io"
 	"iter"
 	"math"
+	"math/big"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -20,7 +23,14 @@ type mantissa struct {
 }
 
 func newmantissa(digits func() int) mantissa {
-	return mantissa{digits: newdigitMemoizer(digits), maxDigits: math.MaxInt}
+	return newmantissaInBase(digits, 10)
+}
+
+func newmantissaInBase(digits func() int, base int) mantissa {
+	return mantissa{
+		digits:    newdigitMemoizerInBase(digits, base),
+		maxDigits: math.MaxInt,
+	}
 }
 
 func (m mantissa) At(posit int) int {
@@ -31,10 +41,64 @@ func (m mantissa) At(posit int) int {
 	return m.digits.At(posit)
 }
 
+func (m mantissa) AtMany(positions []int) []int {
+	result := make([]int, len(positions))
+	m.digits.AtMany(positions, result, m.maxDigits)
+	return result
+}
+
+// Data returns the computed digits of m so that the returned digits
+// include the digit at index if it exists.
+func (m mantissa) Data(index int) digitData {
+	return m.digits.Data(min(index, m.maxDigits-1)).WithMaxLength(m.maxDigits)
+}
+
+// ScanChunks yields the digits of m at positions start and beyond in
+// chunks of size digits along with the position of the first digit in
+// each chunk. Only the last chunk may have fewer than size digits. The
+// chunks share memory with m, so callers must not modify them. size must
+// be positive.
+func (m mantissa) ScanChunks(
+	start, size int, yield func(index int, chunk []int8) bool) {
+	for posit := start; posit < m.maxDigits; posit += size {
+		end := min(posit+size, m.maxDigits)
+		data := m.Data(end - 1)
+		if data.Len() <= posit {
+			return
+		}
+		if !yield(posit, data.Span(posit, end)) {
+			return
+		}
+	}
+}
+
+// Len returns the number of digits at positions start and beyond.
+func (m mantissa) Len(start int) int {
+	return max(m.digits.firstN(m.maxDigits).Len()-start, 0)
+}
+
+// End returns the maximum number of digits and true if m has one.
+func (m mantissa) End() (int, bool) {
+	if m.maxDigits == math.MaxInt {
+		return 0, false
+	}
+	return m.maxDigits, true
+}
+
 func (m mantissa) ReverseScan(start int, yield func(index, value int) bool) {
 	m.digits.ReverseScan(min(start, m.maxDigits), m.maxDigits, yield)
 }
 
+func (m mantissa) ReverseScanInRange(
+	mantissaStart, start, end int, yield func(index, value int) bool) {
+	end = min(end, m.maxDigits)
+	start = max(mantissaStart, start)
+	if start >= end {
+		return
+	}
+	m.digits.ReverseScan(start, end, yield)
+}
+
 func (m mantissa) Scan(start int, yield func(index, value int) bool) {
 	m.digits.Scan(min(start, m.maxDigits), m.maxDigits, yield)
 }
@@ -76,6 +140,11 @@ func (m mantissa) WithMaxDigits(maxDigits int) mantissa {
 	return result
 }
 
+// Done returns true if all the digits of m have been computed.
+func (m mantissa) Done() bool {
+	return m.digits.Done()
+}
+
 func (m mantissa) NumComputed() int {
 	return min(m.digits.NumComputed(), m.maxDigits)
 }
@@ -97,12 +166,95 @@ func (s *sequencePart) AllInRange(start, end int) iter.Seq2[int, int] {
 	}
 }
 
+func (s *sequencePart) AllFrom(
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	return allFrom(s.AllInRange, s.start, token)
+}
+
 func (s *sequencePart) Values() iter.Seq[int] {
 	return func(yield func(value int) bool) {
 		s.mantissa.ScanValues(s.start, yield)
 	}
 }
 
+func (s *sequencePart) Chunks(size int) iter.Seq2[int, []int8] {
+	return chunks(s.mantissa, s.start, size)
+}
+
+func (s *sequencePart) ValuesBase(pow int) iter.Seq[int] {
+	return valuesBase(s.Values(), pow)
+}
+
+func (s *sequencePart) PackedDigits(max int) []byte {
+	return appendPackedDigits(nil, s.Values(), max)
+}
+
+// String returns the first 16 digits of this view followed by "..." if
+// there are more digits.
+func (s *sequencePart) String() string {
+	return valuesString(s.Values())
+}
+
+func chunks(m mantissa, start, size int) iter.Seq2[int, []int8] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	return func(yield func(index int, chunk []int8) bool) {
+		m.ScanChunks(start, size, yield)
+	}
+}
+
+// kMaxValuesBasePow is the most digits that fit in a group that
+// ValuesBase returns.
+const kMaxValuesBasePow = 18
+
+func valuesBase(values iter.Seq[int], pow int) iter.Seq[int] {
+	if pow <= 0 || pow > kMaxValuesBasePow {
+		panic("pow must be between 1 and 18")
+	}
+	return func(yield func(int) bool) {
+		group, count := 0, 0
+		for digit := range values {
+			group = 10*group + digit
+			count++
+			if count == pow {
+				if !yield(group) {
+					return
+				}
+				group, count = 0, 0
+			}
+		}
+		if count > 0 {
+			for ; count < pow; count++ {
+				group *= 10
+			}
+			yield(group)
+		}
+	}
+}
+
+// valuesString returns the first 16 digits in values followed by "..." if
+// there are more digits.
+func valuesString(values iter.Seq[int]) string {
+	return previewString(values, gPrecision, "...")
+}
+
+// previewString returns the first n digits in values followed by
+// ellipsis if there are more digits.
+func previewString(values iter.Seq[int], n int, ellipsis string) string {
+	var sb strings.Builder
+	count := 0
+	for digit := range values {
+		if count >= n {
+			sb.WriteString(ellipsis)
+			break
+		}
+		sb.WriteByte('0' + byte(digit))
+		count++
+	}
+	return sb.String()
+}
+
 func (s *sequencePart) PrimeToStart(ctx context.Context) error {
 	return s.mantissa.PrimeTo(ctx, s.start)
 }
@@ -117,6 +269,21 @@ func (s *sequencePart) backward() iter.Seq2[int, int] {
 	}
 }
 
+func (s *sequencePart) allInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return func(yield func(index, value int) bool) {
+		s.mantissa.ReverseScanInRange(s.start, start, end, yield)
+	}
+}
+
+func (s *sequencePart) Start() int {
+	return s.start
+}
+
+func (s *sequencePart) end() (int, bool) {
+	return s.mantissa.End()
+}
+
 func (s *sequencePart) withStart(start int) sequencePart {
 	result := *s
 	if start > result.start {
@@ -148,27 +315,236 @@ func (n *numberPart) AllInRange(start, end int) iter.Seq2[int, int] {
 	}
 }
 
+func (n *numberPart) AllFrom(
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	return allFrom(n.AllInRange, 0, token)
+}
+
+func (n *numberPart) BackwardFrom(end int) iter.Seq2[int, int] {
+	return n.allInRangeBackward(0, end)
+}
+
 func (n *numberPart) Values() iter.Seq[int] {
 	return func(yield func(value int) bool) {
 		n.mantissa.ScanValues(0, yield)
 	}
 }
 
+func (n *numberPart) Stride(k int) Sequence {
+	return &stridedSequence{newStridedPart(n.mantissa, 0, k)}
+}
+
+func (n *numberPart) Chunks(size int) iter.Seq2[int, []int8] {
+	return chunks(n.mantissa, 0, size)
+}
+
+func (n *numberPart) ValuesBase(pow int) iter.Seq[int] {
+	return valuesBase(n.Values(), pow)
+}
+
+func (n *numberPart) PackedDigits(max int) []byte {
+	return appendPackedDigits(nil, n.Values(), max)
+}
+
 func (n *numberPart) At(posit int) int {
 	return n.mantissa.At(posit)
 }
 
+func (n *numberPart) AtMany(positions []int) []int {
+	return n.mantissa.AtMany(positions)
+}
+
 func (n *numberPart) Exponent() int {
 	return n.exponent
 }
 
 func (n *numberPart) Format(state fmt.State, verb rune) {
-	formatSpec, ok := newFormatSpec(state, verb, n.exponent)
+	n.formatWithOptions(state, verb, formatOptions{})
+}
+
+// formatWithOptions works like Format except that it applies options.
+func (n *numberPart) formatWithOptions(
+	state fmt.State, verb rune, options formatOptions) {
+	precision, precisionOk := state.Precision()
+	formatSpec, target, ok := n.formatSpecFor(
+		verb, precision, precisionOk, options)
 	if !ok {
-		fmt.Fprintf(state, "%%!%c(number=%s)", verb, n.String())
+		var builder strings.Builder
+		formatSpec.PrintNumber(&builder, n)
+		fmt.Fprintf(state, "%%!%c(number=%s)", verb, builder.String())
 		return
 	}
-	formatSpec.PrintField(state, n)
+	switch verb {
+	case 'x', 'X', 'b':
+
+		// As for integers in package fmt, '#' adds a 0x, 0X, or 0b prefix.
+		if state.Flag('#') {
+			formatSpec.radixPrefix = "0" + string(verb)
+		}
+	default:
+		formatSpec.ellipsis = state.Flag('#')
+	}
+	formatSpec.PrintField(state, target)
+}
+
+// AppendFormat appends n formatted according to verb and precision to buf
+// and returns the extended buffer. A negative precision means the default
+// precision for verb.
+func (n *numberPart) AppendFormat(
+	buf []byte, verb rune, precision int) []byte {
+	return n.appendFormatWithOptions(buf, verb, precision, formatOptions{})
+}
+
+// appendFormatWithOptions works like AppendFormat except that it applies
+// options.
+func (n *numberPart) appendFormatWithOptions(
+	buf []byte, verb rune, precision int, options formatOptions) []byte {
+	w := &appendWriter{buf: buf}
+	formatSpec, target, ok := n.formatSpecFor(
+		verb, precision, precision >= 0, options)
+	if !ok {
+		w.WriteString("%!")
+		w.WriteRune(verb)
+		w.WriteString("(number=")
+		formatSpec.PrintNumber(w, n)
+		w.WriteByte(')')
+		return w.buf
+	}
+	formatSpec.PrintNumber(w, target)
+	return w.buf
+}
+
+// WriteDigits writes n to w in fixed-point notation showing no more than
+// count significant digits. WriteDigits returns the number of bytes
+// written and the first error that w returned.
+func (n *numberPart) WriteDigits(w io.Writer, count int) (int, error) {
+	if count < 0 {
+		panic("count must be non-negative")
+	}
+	truncated := n.withEnd(count)
+	formatSpec := formatSpec{sigDigits: max(count, n.exponent)}
+	cw := &countingWriter{w: w}
+	formatSpec.printFixed(cw, truncated.mantissa, n.exponent)
+	return cw.n, cw.err
+}
+
+// LaTeX returns n in scientific notation for LaTeX showing no more than
+// sigDigits significant digits.
+func (n *numberPart) LaTeX(sigDigits int) string {
+	mantissa, exp := n.sciParts(sigDigits)
+	if exp == 0 {
+		return mantissa
+	}
+	return fmt.Sprintf("%s\\times10^{%d}", mantissa, exp)
+}
+
+// MathML returns n in scientific notation as a MathML math element
+// showing no more than sigDigits significant digits.
+func (n *numberPart) MathML(sigDigits int) string {
+	mantissa, exp := n.sciParts(sigDigits)
+	if exp == 0 {
+		return fmt.Sprintf("<math><mn>%s</mn></math>", mantissa)
+	}
+	return fmt.Sprintf(
+		"<math><mn>%s</mn><mo>&#xD7;</mo><msup><mn>10</mn><mn>%d</mn></msup></math>",
+		mantissa,
+		exp)
+}
+
+// sciParts returns the mantissa of n with one digit before the decimal
+// point and no more than sigDigits significant digits along with the
+// power of 10 that goes with it.
+func (n *numberPart) sciParts(sigDigits int) (string, int) {
+	var builder strings.Builder
+	formatSpec := formatSpec{sigDigits: max(sigDigits, 1)}
+	formatSpec.printFixed(&builder, n.mantissa, 1)
+	if n.IsZero() {
+		return builder.String(), 0
+	}
+	return builder.String(), n.exponent - 1
+}
+
+// formatSpecFor returns the formatSpec for printing n with verb along with
+// the numberPart to print. The returned numberPart is n itself except for
+// the x and X verbs, which print n in base 16, and the b verb, which
+// prints n in base 2. For these verbs, precision is the number of digits
+// after the point. If verb is not supported, formatSpecFor returns false
+// along with the formatSpec to use for printing n in the error message.
+func (n *numberPart) formatSpecFor(
+	verb rune, precision int, precisionOk bool, options formatOptions) (
+	formatSpec, *numberPart, bool) {
+	switch verb {
+	case 'x', 'X', 'b':
+		base, digitChars := 16, kLowerDigitChars
+		switch verb {
+		case 'X':
+			digitChars = kUpperDigitChars
+		case 'b':
+			base = 2
+		}
+		var exact *big.Rat
+		if options.source != nil {
+			exact, _ = options.source.AsRat()
+		}
+		digits, exp := newBaseGenerator(n, exact, base).Generate()
+		inBase := &numberPart{
+			exponent: exp, mantissa: newmantissaInBase(digits, base)}
+		if !precisionOk {
+			precision = fPrecision
+		}
+		result := formatSpecForF(precision, inBase.exponent)
+		result.formatOptions = options
+		result.digitChars = digitChars
+		return result, inBase, true
+	}
+	result, ok := decimalFormatSpec(
+		verb, precision, precisionOk, n.exponent, options)
+	if !ok || options.rounding == RoundDown {
+		return result, n, ok
+	}
+	var rounded *FiniteNumber
+	switch verb {
+	case 'f', 'F', 'd':
+		rounded = roundToPlaces(
+			options.source, result.sigDigits-n.exponent, options.rounding)
+	default:
+		rounded = round(options.source, result.sigDigits, options.rounding)
+	}
+
+	// Rounding can change the exponent, which the formatSpec depends on.
+	result, _ = decimalFormatSpec(
+		verb, precision, precisionOk, rounded.exponent, options)
+	return result, &rounded.numberPart, true
+}
+
+// decimalFormatSpec returns the formatSpec for printing a number with the
+// given exponent in base 10 with verb. If verb is not supported,
+// decimalFormatSpec returns false along with the formatSpec to use for
+// printing the number in the error message.
+func decimalFormatSpec(
+	verb rune,
+	precision int,
+	precisionOk bool,
+	exponent int,
+	options formatOptions) (formatSpec, bool) {
+	switch verb {
+	case 'g', 'G', 'v':
+		if !precisionOk && options.defaultPrecision > 0 {
+			precision, precisionOk = options.defaultPrecision, true
+		}
+	}
+	result, ok := newFormatSpec(verb, precision, precisionOk, exponent)
+	if !ok {
+		result = formatSpecForG(gPrecision, exponent, false)
+	}
+	result.formatOptions = options
+	if options.normalizedSci && (verb == 'e' || verb == 'E') {
+
+		// Precision counts the digits after the decimal point, but
+		// normalized scientific notation has a digit before it too.
+		result.sigDigits++
+	}
+	return result, ok
 }
 
 func (n *numberPart) Exact() string {
@@ -189,14 +565,56 @@ func (n *numberPart) PrimeToStart(ctx context.Context) error {
 	return nil
 }
 
+func (n *numberPart) impl() *numberPart {
+	return n
+}
+
 func (n *numberPart) IsZero() bool {
 	return *n == numberPart{}
 }
 
+func (n *numberPart) CopyDigits(dst []int8, start int) int {
+	if start < 0 {
+		panic("start must be non-negative")
+	}
+	if len(dst) == 0 {
+		return 0
+	}
+	return n.mantissa.Data(start+len(dst)-1).CopyTo(dst, start)
+}
+
+func (n *numberPart) Snapshot() *FiniteNumber {
+	count := n.NumComputed()
+	if count == 0 {
+		return &FiniteNumber{}
+	}
+	data := n.mantissa.Data(count - 1)
+	fixed := make([]int, count)
+	for i := range fixed {
+		fixed[i] = int(data.At(i))
+	}
+	return newFiniteNumber(
+		newRepeatingGenerator(fixed, nil, n.exponent).Generate())
+}
+
+func (n *numberPart) DigitsPrefix(count int) []int8 {
+	if count < 0 {
+		panic("count must be non-negative")
+	}
+	if count == 0 {
+		return nil
+	}
+	return n.mantissa.Data(count-1).Span(0, count)
+}
+
 func (n *numberPart) NumComputed() int {
 	return n.mantissa.NumComputed()
 }
 
+func (n *numberPart) KnownDigits() int {
+	return n.NumComputed()
+}
+
 func (n *numberPart) primeToEnd(ctx context.Context) error {
 	return n.mantissa.PrimeToEnd(ctx)
 }
@@ -207,6 +625,21 @@ func (n *numberPart) backward() iter.Seq2[int, int] {
 	}
 }
 
+func (n *numberPart) allInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return func(yield func(index, value int) bool) {
+		n.mantissa.ReverseScanInRange(0, start, end, yield)
+	}
+}
+
+func (n *numberPart) Start() int {
+	return 0
+}
+
+func (n *numberPart) end() (int, bool) {
+	return n.mantissa.End()
+}
+
 func (n *numberPart) withExponent(e int) numberPart {
 	result := *n
 	if !result.IsZero() {
@@ -229,17 +662,48 @@ type formatSpec struct {
 	exactDigitCount bool
 	sci             bool
 	capital         bool
-}
-
-func newFormatSpec(state fmt.State, verb rune, exponent int) (
+	ellipsis        bool
+	radixPrefix     string
+	formatOptions
+}
+
+// formatOptions customizes how a Number prints. If maskChar is non-zero,
+// maskChar prints in place of each significant digit at position maskFrom
+// and beyond. suffix prints right after the number. If digitChars is
+// non-empty, digitChars[d] prints for each digit d. intSep, fracGroup, and
+// fracSep control digit grouping as in formatter.SetGrouping. If
+// normalizedSci is true, scientific notation has one digit before the
+// decimal point instead of none. If expDigits is positive, exponents in
+// scientific notation have at least expDigits digits instead of 2. If
+// source is not nil, it is the Number being printed. If rounding is not
+// RoundDown, the digits of source get rounded instead of truncated. If
+// defaultPrecision is positive, it replaces 16 as the default precision
+// for g, G, and v.
+type formatOptions struct {
+	maskFrom         int
+	maskChar         rune
+	suffix           string
+	digitChars       string
+	intSep           string
+	fracGroup        int
+	fracSep          string
+	normalizedSci    bool
+	expDigits        int
+	rounding         RoundingMode
+	source           Number
+	defaultPrecision int
+}
+
+func newFormatSpec(verb rune, precision int, precisionOk bool, exponent int) (
 	formatSpec, bool) {
-	precision, precisionOk := state.Precision()
 	switch verb {
 	case 'f', 'F':
 		if !precisionOk {
 			precision = fPrecision
 		}
 		return formatSpecForF(precision, exponent), true
+	case 'd':
+		return formatSpecForF(0, exponent), true
 	case 'g', 'G', 'v':
 		if !precisionOk {
 			precision = gPrecision
@@ -279,19 +743,41 @@ func formatSpecForE(precision int, capital bool) formatSpec {
 
 func (f formatSpec) PrintField(state fmt.State, n *numberPart) {
 	width, widthOk := state.Width()
+	prefix := sign(state) + f.radixPrefix
 	if !widthOk {
+		io.WriteString(state, prefix)
 		f.PrintNumber(state, n)
 		return
 	}
 	var builder strings.Builder
 	f.PrintNumber(&builder, n)
-	field := builder.String()
-	if !state.Flag('-') && len(field) < width {
-		fmt.Fprint(state, strings.Repeat(" ", width-len(field)))
+	number := builder.String()
+	padding := width - len(prefix) - utf8.RuneCountInString(number)
+	switch {
+	case padding <= 0:
+		fmt.Fprint(state, prefix, number)
+	case state.Flag('-'):
+		fmt.Fprint(state, prefix, number, strings.Repeat(" ", padding))
+	case state.Flag('0'):
+
+		// Zeros go between the sign and the digits.
+		fmt.Fprint(state, prefix, strings.Repeat("0", padding), number)
+	default:
+		fmt.Fprint(state, strings.Repeat(" ", padding), prefix, number)
 	}
-	fmt.Fprint(state, field)
-	if state.Flag('-') && len(field) < width {
-		fmt.Fprint(state, strings.Repeat(" ", width-len(field)))
+}
+
+// sign returns what to print before a number according to the '+' and ' '
+// flags in state. Since numbers are never negative, sign returns "+" for
+// the '+' flag, " " for the ' ' flag, or the empty string.
+func sign(state fmt.State) string {
+	switch {
+	case state.Flag('+'):
+		return "+"
+	case state.Flag(' '):
+		return " "
+	default:
+		return ""
 	}
 }
 
@@ -305,19 +791,79 @@ func (f formatSpec) PrintNumber(w io.Writer, n *numberPart) {
 	} else {
 		f.printFixed(w, n.mantissa, n.exponent)
 	}
+
+	// The ellipsis goes after any exponent since the elided digits belong
+	// to the mantissa, not the exponent.
+	if f.ellipsis && n.mantissa.At(max(f.sigDigits, 0)) != -1 {
+		io.WriteString(w, "...")
+	}
+	io.WriteString(w, f.suffix)
 }
 
 func (f formatSpec) printFixed(w io.Writer, m mantissa, exponent int) {
 	formatter := newFormatter(w, f.sigDigits, exponent, f.exactDigitCount)
+	formatter.SetMask(f.maskFrom, f.maskChar)
+	formatter.SetGrouping(f.intSep, f.fracGroup, f.fracSep)
+	if f.digitChars != "" {
+		formatter.SetDigitChars(f.digitChars)
+	}
 	fromMantissa(m, formatter)
 	formatter.Finish()
 }
 
 func (f formatSpec) printSci(
 	w io.Writer, m mantissa, exponent int, sep string) {
-	f.printFixed(w, m, 0)
-	fmt.Fprint(w, sep)
-	fmt.Fprintf(w, "%+03d", exponent)
+	if f.normalizedSci {
+		f.printFixed(w, m, 1)
+		if m.At(0) != -1 {
+			exponent--
+		}
+	} else {
+		f.printFixed(w, m, 0)
+	}
+	io.WriteString(w, sep)
+	expDigits := f.expDigits
+	if expDigits <= 0 {
+		expDigits = 2
+	}
+	var buf [24]byte
+	w.Write(appendExponent(buf[:0], exponent, expDigits))
+}
+
+// countingWriter counts the bytes written to w and remembers the first
+// error. Once w returns an error, countingWriter discards further writes.
+type countingWriter struct {
+	w   io.Writer
+	n   int
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += n
+	c.err = err
+	return n, err
+}
+
+// appendExponent appends exponent to dst with a leading sign and at least
+// digits digits.
+func appendExponent(dst []byte, exponent, digits int) []byte {
+	abs := uint64(exponent)
+	if exponent < 0 {
+		dst = append(dst, '-')
+		abs = -abs
+	} else {
+		dst = append(dst, '+')
+	}
+	var buf [20]byte
+	absDigits := strconv.AppendUint(buf[:0], abs, 10)
+	for range digits - len(absDigits) {
+		dst = append(dst, '0')
+	}
+	return append(dst, absDigits...)
 }
 
 func fromMantissa(m mantissa, formatter *formatter) {
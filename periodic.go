@@ -0,0 +1,144 @@
+package sqrt
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DetectPeriod reports whether the first maxDigits significant digits of
+// n are eventually periodic. If so, preperiod is the number of digits
+// before the repeating digits begin, and period is the number of repeating
+// digits. For example, DetectPeriod reports a preperiod of 3 and a period
+// of 4 for 10.2003400340034... If n has no more than maxDigits digits,
+// DetectPeriod returns n's digit count as the preperiod, 0 as the period,
+// and true.
+//
+// Because DetectPeriod can only examine a finite number of digits, it
+// requires that the repeating digits make up at least half of the
+// examined digits and that they repeat at least twice. DetectPeriod
+// returns the smallest preperiod and then the smallest period meeting
+// these requirements. DetectPeriod panics if maxDigits is negative.
+func DetectPeriod(n Number, maxDigits int) (preperiod, period int, ok bool) {
+	if maxDigits < 0 {
+		panic("maxDigits must be non-negative")
+	}
+	data := n.impl().mantissa.Data(maxDigits)
+	if data.Len() <= maxDigits {
+		return data.Len(), 0, true
+	}
+	return detectPeriod(data.Span(0, maxDigits))
+}
+
+func detectPeriod(data []int8) (preperiod, period int, ok bool) {
+	size := len(data)
+	preperiod = size/2 + 1
+	for p := 1; 2*p <= size; p++ {
+		i := size - p - 1
+		for i >= 0 && data[i] == data[i+p] {
+			i--
+		}
+		start := i + 1
+		if start < preperiod && size-start >= 2*p {
+			preperiod, period, ok = start, p, true
+		}
+	}
+	if !ok {
+		return 0, 0, false
+	}
+	return
+}
+
+// cycle describes the repeating digits of a mantissa.
+type cycle struct {
+
+	// start is the number of digits before the repeating digits.
+	start int
+
+	// length is the number of repeating digits.
+	length int
+}
+
+// newCycle returns the cycle of the mantissa having the digits in fixed
+// followed by the digits in repeating repeated forever. newCycle returns
+// the cycle with the smallest start and then the smallest length.
+// repeating must be non-empty.
+func newCycle(fixed, repeating []int) *cycle {
+	digitAt := func(posit int) int {
+		if posit < len(fixed) {
+			return fixed[posit]
+		}
+		return repeating[(posit-len(fixed))%len(repeating)]
+	}
+	length := len(repeating)
+	for p := 1; p < len(repeating); p++ {
+		if len(repeating)%p == 0 && isPeriod(repeating, p) {
+			length = p
+			break
+		}
+	}
+	start := len(fixed)
+	for start > 0 && digitAt(start-1) == digitAt(start-1+length) {
+		start--
+	}
+	return &cycle{start: start, length: length}
+}
+
+// String returns the representation of n which must have this cycle.
+func (c *cycle) String(n *numberPart) string {
+	if c.length == 1 && n.At(c.start) == 0 {
+		fn := FiniteNumber{n.withEnd(c.start)}
+		return fn.Exact()
+	}
+	sci := bigExponent(n.exponent)
+	start := c.start
+	if !sci {
+		start = max(start, n.exponent)
+	}
+	digits := AsString(&FiniteNumber{n.withEnd(start + c.length)})
+	var sb strings.Builder
+	switch {
+	case sci:
+		sb.WriteString("0.")
+		sb.WriteString(digits[:start])
+	case n.exponent > 0:
+		sb.WriteString(digits[:n.exponent])
+		sb.WriteByte('.')
+		sb.WriteString(digits[n.exponent:start])
+	default:
+		sb.WriteString("0.")
+		sb.WriteString(strings.Repeat("0", -n.exponent))
+		sb.WriteString(digits[:start])
+	}
+	sb.WriteByte('(')
+	sb.WriteString(digits[start:])
+	sb.WriteByte(')')
+	if sci {
+		fmt.Fprintf(&sb, "e%+03d", n.exponent)
+	}
+	return sb.String()
+}
+
+// Rat returns the exact value of n which must have this cycle.
+func (c *cycle) Rat(n *numberPart) *big.Rat {
+
+	// 0.F(R) = (F*(10^length - 1) + R) / (10^start * (10^length - 1))
+	digits := &FiniteNumber{n.withEnd(c.start + c.length)}
+	fixed, _ := mantissaInt(digits.WithEnd(c.start))
+	repeating, _ := mantissaInt(digits.FiniteWithStart(c.start))
+	nines := pow10(c.length)
+	nines.Sub(nines, one)
+	num := new(big.Int).Mul(fixed, nines)
+	num.Add(num, repeating)
+	result := new(big.Rat).SetFrac(num, nines)
+	return result.Mul(result, ratTimesPow10(one, n.exponent-c.start))
+}
+
+func isPeriod(digits []int, p int) bool {
+	for i := p; i < len(digits); i++ {
+		if digits[i] != digits[i-p] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package sqrt
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPeriod(t *testing.T) {
+	n, _ := NewNumberForTesting([]int{1, 0, 2}, []int{0, 0, 3, 4}, 2)
+	assertPeriod(t, 3, 4, n, 100)
+	assertPeriod(t, 3, 4, n, 12)
+	assertPeriod(t, 3, 4, n, 11)
+	assertNoPeriod(t, n, 10)
+	n, _ = NewNumberForTesting([]int{1, 2}, []int{1, 2, 1, 2}, 0)
+	assertPeriod(t, 0, 2, n, 100)
+	n, _ = NewNumberForTesting(nil, []int{3}, 0)
+	assertPeriod(t, 0, 1, n, 2)
+	assertNoPeriod(t, n, 1)
+	assertPeriod(t, 0, 6, SqrtRat(1, 49*100), 100)
+	assertPeriod(t, 1, 6, SqrtRat(1, 84*84), 100)
+	assertPeriod(t, 1, 1, SqrtRat(1, 144), 100)
+}
+
+func TestDetectPeriodTerminating(t *testing.T) {
+	assertPeriod(t, 3, 0, Sqrt(100489), 100)
+	assertPeriod(t, 3, 0, Sqrt(100489), 3)
+	assertPeriod(t, 0, 0, zeroNumber, 100)
+	n, _ := NewFiniteNumber([]int{1, 1, 1, 1}, 0)
+	assertPeriod(t, 4, 0, n, 4)
+	assertPeriod(t, 0, 1, n, 3)
+}
+
+func TestDetectPeriodNone(t *testing.T) {
+	assertNoPeriod(t, Sqrt(2), 1000)
+	assertNoPeriod(t, CubeRoot(2), 3)
+	assertNoPeriod(t, Sqrt(2), 0)
+}
+
+func TestDetectPeriodPanics(t *testing.T) {
+	assert.Panics(t, func() { DetectPeriod(Sqrt(2), -1) })
+}
+
+func assertPeriod(
+	t *testing.T, preperiod, period int, n Number, maxDigits int) {
+	t.Helper()
+	actualPreperiod, actualPeriod, ok := DetectPeriod(n, maxDigits)
+	assert.True(t, ok)
+	assert.Equal(t, preperiod, actualPreperiod)
+	assert.Equal(t, period, actualPeriod)
+}
+
+func assertNoPeriod(t *testing.T, n Number, maxDigits int) {
+	t.Helper()
+	_, _, ok := DetectPeriod(n, maxDigits)
+	assert.False(t, ok)
+}
+
+func TestPeriodic(t *testing.T) {
+	assertPeriodic(t, "10.2(0034)", []int{1, 0, 2}, []int{0, 0, 3, 4}, 2)
+	assertPeriodic(t, "0.(12)", []int{1, 2}, []int{1, 2, 1, 2}, 0)
+	assertPeriodic(t, "12121.(21)", []int{1, 2}, []int{1, 2}, 5)
+	assertPeriodic(t, "0.00(3)", []int{3}, []int{3}, -2)
+	assertPeriodic(t, "0.1(2)e+10", []int{1}, []int{2}, 10)
+	assertPeriodic(t, "0.(12)e-04", nil, []int{1, 2}, -4)
+	assertPeriodic(t, "150", []int{1, 5}, []int{0, 0}, 3)
+	assertPeriodic(t, "0.0015", []int{1, 5}, []int{0}, -2)
+}
+
+func TestPeriodicWithExponent(t *testing.T) {
+	n, _ := NewNumberForTesting([]int{1, 0, 2}, []int{0, 0, 3, 4}, 2)
+	assert.Equal(t, "1.02(0034)", n.withExponent(1).Periodic())
+}
+
+func TestPeriodicNotKnown(t *testing.T) {
+	assert.Equal(t, "317", Sqrt(100489).Periodic())
+	assert.Equal(t, "1.4142", Sqrt(2).WithSignificant(5).Periodic())
+	assert.Equal(t, "0", zeroNumber.Periodic())
+	assert.Empty(t, Sqrt(2).Periodic())
+}
+
+func assertPeriodic(
+	t *testing.T, expected string, fixed, repeating []int, exp int) {
+	t.Helper()
+	n, err := NewNumberForTesting(fixed, repeating, exp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, n.Periodic())
+	}
+}
+
+func TestAsRat(t *testing.T) {
+	assertRat(
+		t,
+		big.NewRat(1020034-102, 99990),
+		[]int{1, 0, 2},
+		[]int{0, 0, 3, 4},
+		2)
+	assertRat(t, big.NewRat(4, 33), []int{1, 2}, []int{1, 2, 1, 2}, 0)
+	assertRat(t, big.NewRat(1, 3000), []int{3}, []int{3}, -3)
+	assertRat(t, big.NewRat(150, 1), []int{1, 5}, []int{0, 0}, 3)
+	assertRat(t, big.NewRat(11, 9), []int{1}, []int{2}, 1)
+}
+
+func TestAsRatFinite(t *testing.T) {
+	r, ok := Sqrt(100489).AsRat()
+	assert.True(t, ok)
+	assert.Equal(t, big.NewRat(317, 1), r)
+	r, ok = SqrtRat(1, 400).AsRat()
+	assert.True(t, ok)
+	assert.Equal(t, big.NewRat(1, 20), r)
+	r, ok = Sqrt(2).WithSignificant(3).AsRat()
+	assert.True(t, ok)
+	assert.Equal(t, big.NewRat(141, 100), r)
+	r, ok = zeroNumber.AsRat()
+	assert.True(t, ok)
+	assert.Zero(t, r.Sign())
+}
+
+func TestAsRatNotKnown(t *testing.T) {
+	_, ok := Sqrt(2).AsRat()
+	assert.False(t, ok)
+	n := NewNumber(newRepeatingGenerator([]int{1, 2, 5}, nil, -1))
+	_, ok = n.AsRat()
+	assert.False(t, ok)
+	assert.Equal(t, -1, n.At(3))
+	r, ok := n.AsRat()
+	assert.True(t, ok)
+	assert.Equal(t, big.NewRat(1, 80), r)
+}
+
+func assertRat(
+	t *testing.T, expected *big.Rat, fixed, repeating []int, exp int) {
+	t.Helper()
+	n, err := NewNumberForTesting(fixed, repeating, exp)
+	if assert.NoError(t, err) {
+		r, ok := n.AsRat()
+		assert.True(t, ok)
+		assert.Equal(t, expected, r)
+	}
+}
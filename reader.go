@@ -0,0 +1,166 @@
+package sqrt
+
+import (
+	"errors"
+	"io"
+)
+
+// DigitReader returns an io.Reader that reads the digits of s as ASCII
+// characters '0' through '9'. The returned reader computes digits only as
+// it reads them, so it can stream infinitely many digits. DigitReader
+// does not include a decimal point because a Sequence has no exponent;
+// use DecimalReader for that.
+func DigitReader(s Sequence) io.Reader {
+	return &digitReader{s: s}
+}
+
+type digitReader struct {
+	s Sequence
+}
+
+func (r *digitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := 0
+	last := -1
+	for index, digit := range r.s.All() {
+		p[n] = '0' + byte(digit)
+		n++
+		last = index
+		if n == len(p) {
+			break
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	r.s = r.s.WithStart(last + 1)
+	return n, nil
+}
+
+// DecimalReader returns an io.Reader that reads n in fixed-point notation
+// with a '.' where the exponent of n puts the decimal point, for example
+// "1.414..." for Sqrt(2) or "0.00125" for 0.125e-2. DecimalReader reads
+// the same text that n.WriteDigits writes when count is large enough
+// to include every digit. Like DigitReader, the returned reader computes
+// digits only as it reads them, so it can stream infinitely many digits.
+func DecimalReader(n Number) io.Reader {
+	r := &decimalReader{n: n}
+	switch exp := n.Exponent(); {
+	case n.IsZero():
+		r.pending = "0"
+		r.done = true
+	case exp <= 0:
+		r.pending = "0."
+		r.zeros = -exp
+	default:
+		r.intEnd = exp
+		r.point = true
+	}
+	return r
+}
+
+type decimalReader struct {
+	n Number
+
+	// pending is what to read before anything else.
+	pending string
+
+	// zeros is how many '0's to read after pending.
+	zeros int
+
+	// next is the position of the next mantissa digit to read.
+	next int
+
+	// intEnd is where the integer part ends. Integer digits past the last
+	// mantissa digit read as '0'.
+	intEnd int
+
+	// point is true if a '.' goes before the next fraction digit.
+	point bool
+
+	// done is true if there is nothing after pending.
+	done bool
+}
+
+func (r *decimalReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	for n < len(p) && r.zeros > 0 {
+		p[n] = '0'
+		n++
+		r.zeros--
+	}
+	if n < len(p) && r.next < r.intEnd {
+		end := r.next + min(len(p)-n, r.intEnd-r.next)
+		for _, digit := range r.n.AllInRange(r.next, end) {
+			p[n] = '0' + byte(digit)
+			n++
+			r.next++
+		}
+
+		// The mantissa ran out before the decimal point.
+		for ; r.next < end; r.next++ {
+			p[n] = '0'
+			n++
+		}
+	}
+	if n < len(p) && r.next >= r.intEnd && !r.done {
+		if r.point {
+			if r.n.At(r.next) == -1 {
+				r.done = true
+			} else {
+				p[n] = '.'
+				n++
+				r.point = false
+			}
+		}
+		if n < len(p) && !r.done {
+			end := r.next + len(p) - n
+			for _, digit := range r.n.AllInRange(r.next, end) {
+				p[n] = '0' + byte(digit)
+				n++
+				r.next++
+			}
+			if r.next < end {
+				r.done = true
+			}
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// DigitReaderAt returns an io.ReaderAt that reads the digits of n as ASCII
+// characters '0' through '9'. The byte at offset i is the digit at 0 based
+// position i in the mantissa of n. Like n itself, the returned ReaderAt
+// computes digits only as needed and is safe to use from multiple
+// goroutines.
+func DigitReaderAt(n Number) io.ReaderAt {
+	return digitReaderAt{n: n}
+}
+
+type digitReaderAt struct {
+	n Number
+}
+
+func (r digitReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("sqrt: negative offset")
+	}
+	n := 0
+	for _, digit := range r.n.AllInRange(int(off), int(off)+len(p)) {
+		p[n] = '0' + byte(digit)
+		n++
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,144 @@
+package sqrt
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigitReader(t *testing.T) {
+	n := Sqrt(2)
+	expected := AsString(n.WithEnd(1000))
+	actual, err := io.ReadAll(io.LimitReader(DigitReader(n), 1000))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestDigitReaderFinite(t *testing.T) {
+	s := Sqrt(2).WithStart(3).WithEnd(503)
+	assert.NoError(t, iotest.TestReader(DigitReader(s), []byte(AsString(s))))
+	var sb strings.Builder
+	_, err := io.Copy(&sb, DigitReader(s))
+	assert.NoError(t, err)
+	assert.Equal(t, AsString(s), sb.String())
+}
+
+func TestDigitReaderEmpty(t *testing.T) {
+	actual, err := io.ReadAll(DigitReader(zeroNumber))
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestDecimalReader(t *testing.T) {
+	for _, n := range []Number{
+		Sqrt(256),
+		MustFinite([]int{1, 2, 5}, 1),
+		MustFinite([]int{1, 2, 5}, -2),
+		MustFinite([]int{1, 2, 5}, 6),
+		MustFinite([]int{1, 2, 5}, 3),
+		Sqrt(2).WithSignificant(300),
+		InvSqrt(1000000).WithSignificant(50),
+		zeroNumber,
+	} {
+		var sb strings.Builder
+		_, err := n.WriteDigits(&sb, 1000)
+		assert.NoError(t, err)
+		assert.NoError(
+			t, iotest.TestReader(DecimalReader(n), []byte(sb.String())))
+	}
+	assert.Equal(t, "0.00125", readAllString(t, DecimalReader(
+		MustFinite([]int{1, 2, 5}, -2))))
+	assert.Equal(t, "125000", readAllString(t, DecimalReader(
+		MustFinite([]int{1, 2, 5}, 6))))
+	assert.Equal(t, "0", readAllString(t, DecimalReader(zeroNumber)))
+}
+
+func TestDecimalReaderInfinite(t *testing.T) {
+	var sb strings.Builder
+	_, err := Sqrt(200).WriteDigits(&sb, 1000)
+	assert.NoError(t, err)
+	actual, err := io.ReadAll(io.LimitReader(DecimalReader(Sqrt(200)), 1001))
+	assert.NoError(t, err)
+	assert.Equal(t, sb.String(), string(actual))
+}
+
+func readAllString(t *testing.T, r io.Reader) string {
+	t.Helper()
+	result, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return string(result)
+}
+
+func TestDigitReaderAt(t *testing.T) {
+	n := Sqrt(2)
+	r := DigitReaderAt(n)
+	p := make([]byte, 10)
+	count, err := r.ReadAt(p, 995)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, count)
+	assert.Equal(t, AsString(n.WithStart(995).WithEnd(1005)), string(p))
+	count, err = r.ReadAt(p[:3], 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, "141", string(p[:3]))
+	_, err = r.ReadAt(p, -1)
+	assert.Error(t, err)
+}
+
+func TestDigitReaderAtFinite(t *testing.T) {
+	r := DigitReaderAt(Sqrt(2).WithSignificant(20))
+	p := make([]byte, 10)
+	count, err := r.ReadAt(p, 15)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, "50488", string(p[:count]))
+	count, err = r.ReadAt(p, 20)
+	assert.Equal(t, io.EOF, err)
+	assert.Zero(t, count)
+	section := io.NewSectionReader(r, 0, 20)
+	actual, err := io.ReadAll(section)
+	assert.NoError(t, err)
+	assert.Equal(t, "14142135623730950488", string(actual))
+}
+
+func TestWriteTo(t *testing.T) {
+	var _ io.WriterTo = Sqrt(2).WithSignificant(10)
+	s := Sqrt(2).WithStart(5).WithEnd(10005)
+	var sb strings.Builder
+	count, err := s.WriteTo(&sb)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), count)
+	assert.Equal(t, AsString(s), sb.String())
+	sb.Reset()
+	count, err = Sqrt(2).WithSignificant(7).WriteTo(&sb)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+	assert.Equal(t, "1414213", sb.String())
+}
+
+func TestWriteToError(t *testing.T) {
+	w := &failingWriter{limit: 5000}
+	count, err := Sqrt(2).WithEnd(10000).WriteTo(w)
+	assert.Equal(t, errWriteFailed, err)
+	assert.Less(t, count, int64(10000))
+}
+
+func TestByteValues(t *testing.T) {
+	var actual []byte
+	for ch := range ByteValues(Sqrt(2).WithStart(2)) {
+		actual = append(actual, ch)
+		if len(actual) == 8 {
+			break
+		}
+	}
+	assert.Equal(t, "14213562", string(actual))
+	assert.Equal(
+		t,
+		[]byte("141421"),
+		slices.Collect(ByteValues(Sqrt(2).WithSignificant(6))))
+	assert.Empty(t, slices.Collect(ByteValues(zeroNumber)))
+}
@@ -0,0 +1,49 @@
+package sqrt
+
+// Find returns the zero based position of the first occurrence of the
+// digits of pattern within the first limit significant digits of n.
+// If pattern does not occur there, Find returns -1. Find returns 0 if
+// pattern is empty. Find computes the digits of n only as needed, so
+// it stops computing digits as soon as it finds a match.
+func Find(n Number, pattern FiniteSequence, limit int) int {
+	var p []int8
+	for digit := range pattern.Values() {
+		p = append(p, int8(digit))
+	}
+	return find(n.impl().mantissa, p, limit)
+}
+
+// find uses the Boyer-Moore-Horspool algorithm to find pattern within the
+// first limit digits of m.
+func find(m mantissa, pattern []int8, limit int) int {
+	size := len(pattern)
+	if size == 0 {
+		return 0
+	}
+	var shifts [10]int
+	for i := range shifts {
+		shifts[i] = size
+	}
+	for i, digit := range pattern[:size-1] {
+		shifts[digit] = size - 1 - i
+	}
+	var data digitData
+	for posit := 0; posit+size <= limit; {
+		last := posit + size - 1
+		if last >= data.Len() {
+			data = m.Data(last)
+			if last >= data.Len() {
+				return -1
+			}
+		}
+		j := size - 1
+		for j >= 0 && data.At(posit+j) == pattern[j] {
+			j--
+		}
+		if j < 0 {
+			return posit
+		}
+		posit += shifts[data.At(last)]
+	}
+	return -1
+}
@@ -0,0 +1,37 @@
+package sqrt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, 100, Find(n, n.WithStart(100).WithEnd(110), 1000))
+	assert.Equal(t, -1, Find(n, n.WithStart(100).WithEnd(110), 109))
+	assert.Equal(t, 100, Find(n, n.WithStart(100).WithEnd(110), 110))
+	assert.Equal(t, 106, Find(Sqrt(3), n.WithEnd(3), 1000))
+	assert.Equal(t, 106, Find(Sqrt(3), n.WithEnd(4), 1000))
+	assert.Equal(t, -1, Find(Sqrt(3), n.WithEnd(6), 2000))
+}
+
+func TestFindShortPattern(t *testing.T) {
+	pattern, _ := NewFiniteNumber([]int{1, 4}, 0)
+	assert.Equal(t, 85, Find(Sqrt(3), pattern, 1000))
+	pattern, _ = NewFiniteNumber([]int{7}, 0)
+	assert.Equal(t, 1, Find(Sqrt(3), pattern, 1000))
+}
+
+func TestFindEmptyPattern(t *testing.T) {
+	assert.Equal(t, 0, Find(Sqrt(3), zeroNumber, 1000))
+	assert.Equal(t, 0, Find(zeroNumber, zeroNumber, 1000))
+}
+
+func TestFindFiniteNumber(t *testing.T) {
+	n, _ := NewFiniteNumber([]int{1, 2, 1, 2, 3}, 0)
+	pattern, _ := NewFiniteNumber([]int{1, 2, 3}, 0)
+	assert.Equal(t, 2, Find(n, pattern, 1000))
+	assert.Equal(t, -1, Find(n.WithSignificant(4), pattern, 1000))
+	assert.Equal(t, -1, Find(zeroNumber, pattern, 1000))
+}
@@ -1,15 +1,22 @@
 package sqrt
 
 import (
+	"bufio"
 	"context"
+	"io"
 	"iter"
+	"math"
+	"slices"
 	"strings"
 )
 
 // Sequence represents a sequence of digits of either finite or infinite
-// length within the mantissa of a real number. Although they can start
-// and optionally end anywhere within a mantissa, Sequences must be
-// contiguous. That is they can have no gaps in the middle.
+// length within the mantissa of a real number. Sequences can start and
+// optionally end anywhere within a mantissa. Each digit keeps its 0 based
+// position within the mantissa. Numbers and the views that WithStart and
+// WithEnd return are contiguous, but views such as those from Stride can
+// have gaps in the middle, so consecutive digits need not have
+// consecutive positions.
 type Sequence interface {
 
 	// All returns the 0 based position and value of each digit in this
@@ -25,10 +32,22 @@ type Sequence interface {
 	// beginning to end.
 	Values() iter.Seq[int]
 
+	// AllFrom returns each digit in this Sequence starting where token
+	// says along with the token for resuming right after that digit.
+	// Pass the zero ResumeToken to start at the beginning. AllFrom panics
+	// if token is negative.
+	AllFrom(token ResumeToken) iter.Seq2[ResumeToken, int]
+
 	// WithStart returns a view of this Sequence that only has digits with
 	// zero based positions greater than or equal to start.
 	WithStart(start int) Sequence
 
+	// Start returns the smallest 0 based position this Sequence may have
+	// a digit at. Start does not compute any digits, so this Sequence
+	// need not have a digit at position Start. For a view with gaps,
+	// Start is a lower bound on the position of the first digit.
+	Start() int
+
 	// WithEnd returns a view of this Sequence that only has digits with
 	// zero based positions less than end.
 	WithEnd(end int) FiniteSequence
@@ -37,6 +56,34 @@ type Sequence interface {
 	// that this sequence can be iterated over without any initial lag.
 	PrimeToStart(ctx context.Context) error
 
+	// Chunks returns the digits of this Sequence in blocks of size digits
+	// along with the 0 based position of the first digit in each block.
+	// Only the last block may have fewer than size digits. Iterating over
+	// blocks is much faster than iterating over digits one at a time. The
+	// blocks may share memory with this Sequence, so callers must not
+	// modify them. Chunks panics if size is not positive.
+	Chunks(size int) iter.Seq2[int, []int8]
+
+	// ValuesBase returns the digits of this Sequence in groups of pow
+	// digits with each group as an int, for example 14, 14, 21 for
+	// 1.41421 when pow is 2. The groups are the digits in base 10^pow. If
+	// the last group is short, ValuesBase fills it out with trailing
+	// zeros. ValuesBase panics if pow is not between 1 and 18.
+	ValuesBase(pow int) iter.Seq[int]
+
+	// PackedDigits returns the first max digits of this Sequence packed
+	// two per byte as binary coded decimal. The first digit of each pair
+	// goes in the high 4 bits. If there is an odd number of digits, the
+	// low 4 bits of the last byte are 0xF. PackedDigits panics if max is
+	// negative.
+	PackedDigits(max int) []byte
+
+	// Stride returns a view of this Sequence that has every kth digit
+	// starting with the first one. The digits in the returned view keep
+	// their 0 based positions. If this Sequence is finite, so is the
+	// returned view. Stride panics if k is not positive.
+	Stride(k int) Sequence
+
 	private()
 }
 
@@ -56,6 +103,119 @@ type FiniteSequence interface {
 	// that this sequence can be iterated over with Backward without any
 	// initial lag.
 	PrimeToEnd(ctx context.Context) error
+
+	// IsEmpty returns true if this FiniteSequence has no digits. IsEmpty
+	// computes at most the first digit of this FiniteSequence except for
+	// views from FilterSeq. For those, IsEmpty computes digits until one
+	// passes the filter, so it may compute every digit in range.
+	IsEmpty() bool
+
+	// End returns the 0 based position that this FiniteSequence ends
+	// before along with true if WithEnd or WithSignificant set an end.
+	// Otherwise End returns 0 and false, in which case this
+	// FiniteSequence ends wherever its digits run out. End does not
+	// compute any digits. For a view with gaps, End bounds the positions
+	// of the digits, so the last digit may be well before End, and
+	// End minus Start need not equal Len.
+	End() (int, bool)
+
+	// AllInRangeBackward works like AllInRange except that it returns the
+	// digits from position end - 1 down to position start.
+	AllInRangeBackward(start, end int) iter.Seq2[int, int]
+
+	// WriteTo writes the digits of this FiniteSequence to w as ASCII
+	// characters '0' through '9' without building a string of them first.
+	// WriteTo returns the number of bytes written and the first error
+	// that w returned. WriteTo implements io.WriterTo.
+	WriteTo(w io.Writer) (int64, error)
+
+	// Len returns the number of digits in this FiniteSequence. Len
+	// computes any digits not yet computed.
+	Len() int
+
+	// Reversed returns the digits of this FiniteSequence in reverse order
+	// as a FiniteSequence. The positions in the returned FiniteSequence
+	// start at 0.
+	Reversed() FiniteSequence
+
+	// At returns the digit at the 0 based position posit in this
+	// FiniteSequence. If posit is outside this FiniteSequence, At returns
+	// -1.
+	At(posit int) int
+}
+
+// AsFiniteSequence returns s as a FiniteSequence and true if s has a
+// finite number of digits. Otherwise it returns nil and false. For
+// example, WithEnd always returns a FiniteSequence while WithStart on a
+// Number with infinite digits does not. AsFiniteSequence never computes
+// any digits.
+func AsFiniteSequence(s Sequence) (FiniteSequence, bool) {
+	result, ok := s.(FiniteSequence)
+	return result, ok
+}
+
+// Take returns a view of the first n digits in s. If s has fewer than n
+// digits, Take returns a view of all of them. If n is not positive, Take
+// returns an empty view. For views with gaps, such as those from Stride,
+// Take computes the first n digits of s to find where the nth one is.
+func Take(s Sequence, n int) FiniteSequence {
+	if n <= 0 || contiguous(s) {
+		return s.WithEnd(offsetFromStart(s, n))
+	}
+	posit, ok := nthPosition(s, n)
+	if !ok {
+		return s.WithEnd(math.MaxInt)
+	}
+	return s.WithEnd(posit + 1)
+}
+
+// Drop returns a view of the digits in s after the first n. If n is not
+// positive, Drop returns s. For views with gaps, such as those from
+// Stride, Drop computes the first n digits of s to find where the nth one
+// is.
+func Drop(s Sequence, n int) Sequence {
+	if n <= 0 {
+		return s
+	}
+	if contiguous(s) {
+		return s.WithStart(offsetFromStart(s, n))
+	}
+	posit, ok := nthPosition(s, n)
+	if !ok {
+		return s.WithStart(math.MaxInt)
+	}
+	return s.WithStart(posit + 1)
+}
+
+// nthPosition returns the position of the nth digit of s counting from 1
+// and true. If s has fewer than n digits, nthPosition returns false. n
+// must be positive.
+func nthPosition(s Sequence, n int) (int, bool) {
+	count := 0
+	for posit := range s.All() {
+		count++
+		if count == n {
+			return posit, true
+		}
+	}
+	return 0, false
+}
+
+// LastN returns a view of the last n digits of s. If s has fewer than n
+// digits, LastN returns s. If n is not positive, LastN returns an empty
+// view. LastN computes all the digits of s.
+func LastN(s FiniteSequence, n int) FiniteSequence {
+	if n <= 0 {
+		return s.FiniteWithStart(math.MaxInt)
+	}
+	count := 0
+	for posit := range s.Backward() {
+		count++
+		if count == n {
+			return s.FiniteWithStart(posit)
+		}
+	}
+	return s
 }
 
 // AsString returns all the digits in s as a string.
@@ -67,6 +227,218 @@ func AsString(s FiniteSequence) string {
 	return sb.String()
 }
 
+// Preview returns up to the first n digits of s followed by "…" if s has
+// more digits. Unlike AsString, Preview is safe to call on a Sequence
+// with infinite digits, so it is suitable for log and error messages.
+// Preview computes at most n + 1 digits of s.
+func Preview(s Sequence, n int) string {
+	return previewString(s.Values(), n, "…")
+}
+
+// ByteValues returns the digits of s as ASCII characters '0' through '9'
+// from beginning to end.
+func ByteValues(s Sequence) iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for digit := range s.Values() {
+			if !yield('0' + byte(digit)) {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns the position and digits of a and b at each position where
+// both a and b have a digit in order of position. Zip ends when either a
+// or b runs out of digits.
+func Zip(a, b Sequence) iter.Seq2[int, [2]int] {
+	return func(yield func(index int, digits [2]int) bool) {
+		nextB, stop := iter.Pull2(b.All())
+		defer stop()
+		posB, digitB, ok := nextB()
+		for posA, digitA := range a.All() {
+			for ok && posB < posA {
+				posB, digitB, ok = nextB()
+			}
+			if !ok {
+				return
+			}
+			if posB == posA && !yield(posA, [2]int{digitA, digitB}) {
+				return
+			}
+		}
+	}
+}
+
+// kScanChunkSize is how many digits at a time the functions that scan
+// whole FiniteSequences, such as SequenceEqual and Frequencies, fetch.
+const kScanChunkSize = 4096
+
+// SequenceEqual returns true if a and b have the same digits at the same
+// positions. SequenceEqual stops at the first difference.
+func SequenceEqual(a, b FiniteSequence) bool {
+	if !contiguous(a) || !contiguous(b) {
+		return positionedChunksEqual(a, b)
+	}
+
+	// Because a and b have no gaps, the same start and the same digits
+	// mean the same positions.
+	nextB, stop := iter.Pull2(b.Chunks(kScanChunkSize))
+	defer stop()
+	for startA, chunkA := range a.Chunks(kScanChunkSize) {
+		startB, chunkB, ok := nextB()
+		if !ok || startA != startB || !slices.Equal(chunkA, chunkB) {
+			return false
+		}
+	}
+	_, _, ok := nextB()
+	return !ok
+}
+
+// CompareSequences compares the digits of a and b lexicographically
+// ignoring positions. It returns -1 if a comes before b, 1 if a comes
+// after b, and 0 if a and b have the same digits. If a is a proper prefix
+// of b, a comes before b.
+func CompareSequences(a, b FiniteSequence) int {
+	nextB, stop := iter.Pull2(b.Chunks(kScanChunkSize))
+	defer stop()
+
+	// Both sides use the same chunk size, so only the last chunk of each
+	// can be short, and a short chunk ends its sequence.
+	for _, chunkA := range a.Chunks(kScanChunkSize) {
+		_, chunkB, ok := nextB()
+		if !ok {
+			return 1
+		}
+		if result := slices.Compare(chunkA, chunkB); result != 0 {
+			return result
+		}
+	}
+	if _, _, ok := nextB(); ok {
+		return -1
+	}
+	return 0
+}
+
+// contiguous returns true if s has a digit at every position from its
+// first digit to its last.
+func contiguous(s Sequence) bool {
+	switch s.(type) {
+	case *number, *FiniteNumber, *sequence, *finiteSequence:
+		return true
+	}
+	return false
+}
+
+// positionedChunk holds consecutive digits of a Sequence along with their
+// positions.
+type positionedChunk struct {
+	positions []int
+	digits    []int8
+}
+
+// positionedChunks returns the digits of s in chunks of size digits along
+// with their positions. Only the last chunk may have fewer than size
+// digits.
+func positionedChunks(s Sequence, size int) iter.Seq[positionedChunk] {
+	return func(yield func(chunk positionedChunk) bool) {
+		var chunk positionedChunk
+		for posit, digit := range s.All() {
+			chunk.positions = append(chunk.positions, posit)
+			chunk.digits = append(chunk.digits, int8(digit))
+			if len(chunk.digits) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = positionedChunk{}
+			}
+		}
+		if len(chunk.digits) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// positionedChunksEqual works like SequenceEqual for Sequences that may
+// have gaps.
+func positionedChunksEqual(a, b FiniteSequence) bool {
+	nextB, stop := iter.Pull(positionedChunks(b, kScanChunkSize))
+	defer stop()
+	for chunkA := range positionedChunks(a, kScanChunkSize) {
+		chunkB, ok := nextB()
+		if !ok || !slices.Equal(chunkA.positions, chunkB.positions) ||
+			!slices.Equal(chunkA.digits, chunkB.digits) {
+			return false
+		}
+	}
+	_, ok := nextB()
+	return !ok
+}
+
+// Frequencies returns how many times each digit appears in s. The value
+// at index i is the number of times digit i appears. Frequencies computes
+// any digits of s not yet computed.
+func Frequencies(s FiniteSequence) [10]int {
+	var result [10]int
+	for _, chunk := range s.Chunks(kScanChunkSize) {
+		for _, digit := range chunk {
+			result[digit]++
+		}
+	}
+	return result
+}
+
+// Count returns the number of times digit appears in s. Count computes
+// any digits of s not yet computed.
+func Count(s FiniteSequence, digit int) int {
+	var result int
+	for _, chunk := range s.Chunks(kScanChunkSize) {
+		for _, d := range chunk {
+			if int(d) == digit {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+// CountFunc returns the number of digits in s for which f returns true.
+// CountFunc computes any digits of s not yet computed.
+func CountFunc(s FiniteSequence, f func(digit int) bool) int {
+	var result int
+	for _, chunk := range s.Chunks(kScanChunkSize) {
+		for _, digit := range chunk {
+			if f(int(digit)) {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+// offsetFromStart returns s.Start() + max(n, 0) without overflowing.
+func offsetFromStart(s Sequence, n int) int {
+	start := s.Start()
+	if n <= 0 {
+		return start
+	}
+	if start > 0 && n > math.MaxInt-start {
+		return math.MaxInt
+	}
+	return start + n
+}
+
+func writeTo(w io.Writer, s FiniteSequence) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for digit := range s.Values() {
+		if bw.WriteByte('0'+byte(digit)) != nil {
+			break
+		}
+	}
+	bw.Flush()
+	return int64(cw.n), cw.err
+}
+
 type sequence struct {
 	sequencePart
 }
@@ -83,6 +455,10 @@ func (s *sequence) WithEnd(end int) FiniteSequence {
 	return &finiteSequence{s.withEnd(end)}
 }
 
+func (s *sequence) Stride(k int) Sequence {
+	return &stridedSequence{newStridedPart(s.mantissa, s.start, k)}
+}
+
 func (s *sequence) private() {
 }
 
@@ -114,9 +490,45 @@ func (f *finiteSequence) Backward() iter.Seq2[int, int] {
 	return f.backward()
 }
 
+func (f *finiteSequence) IsEmpty() bool {
+	return f.start >= f.mantissa.maxDigits || f.mantissa.At(f.start) == -1
+}
+
+func (f *finiteSequence) End() (int, bool) {
+	return f.end()
+}
+
+func (f *finiteSequence) AllInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return f.allInRangeBackward(start, end)
+}
+
 func (f *finiteSequence) PrimeToEnd(ctx context.Context) error {
 	return f.primeToEnd(ctx)
 }
 
+func (f *finiteSequence) Stride(k int) Sequence {
+	return &finiteStridedSequence{newStridedPart(f.mantissa, f.start, k)}
+}
+
+func (f *finiteSequence) At(posit int) int {
+	if posit < f.start {
+		return -1
+	}
+	return f.mantissa.At(posit)
+}
+
+func (f *finiteSequence) Reversed() FiniteSequence {
+	return reversed(f)
+}
+
+func (f *finiteSequence) Len() int {
+	return f.mantissa.Len(f.start)
+}
+
+func (f *finiteSequence) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, f)
+}
+
 func (f *finiteSequence) private() {
 }
@@ -0,0 +1,20 @@
+package sqrt
+
+// DigitSink consumes digits that Number.Drive sends it. Formatters,
+// hashers, and statistics collectors can implement DigitSink to receive
+// digits with less overhead than ranging over an iter.Seq2.
+type DigitSink interface {
+
+	// Consume receives the digit at 0 based position index. Consume
+	// returns false to stop receiving digits.
+	Consume(index, digit int) bool
+
+	// Flush finishes consuming digits. Drive calls Flush once after the
+	// last call to Consume and returns what Flush returns.
+	Flush() error
+}
+
+func (n *numberPart) Drive(sink DigitSink, start, end int) error {
+	n.mantissa.ScanInRange(0, start, end, sink.Consume)
+	return sink.Flush()
+}
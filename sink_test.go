@@ -0,0 +1,65 @@
+package sqrt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type digitSum struct {
+	sum     int
+	count   int
+	first   int
+	limit   int
+	flushed bool
+	err     error
+}
+
+func (d *digitSum) Consume(index, digit int) bool {
+	if d.count == 0 {
+		d.first = index
+	}
+	d.sum += digit
+	d.count++
+	return d.limit == 0 || d.count < d.limit
+}
+
+func (d *digitSum) Flush() error {
+	d.flushed = true
+	return d.err
+}
+
+func TestDrive(t *testing.T) {
+	var sink digitSum
+	assert.NoError(t, Sqrt(2).Drive(&sink, 2, 7))
+	assert.Equal(t, 1+4+2+1+3, sink.sum)
+	assert.Equal(t, 5, sink.count)
+	assert.Equal(t, 2, sink.first)
+	assert.True(t, sink.flushed)
+}
+
+func TestDriveStopsEarly(t *testing.T) {
+	sink := digitSum{limit: 3}
+	assert.NoError(t, Sqrt(2).Drive(&sink, 0, 1000))
+	assert.Equal(t, 1+4+1, sink.sum)
+	assert.Equal(t, 3, sink.count)
+	assert.True(t, sink.flushed)
+}
+
+func TestDriveFinite(t *testing.T) {
+	var sink digitSum
+	n := Sqrt(2).WithSignificant(4)
+	assert.NoError(t, n.Drive(&sink, 0, 1000))
+	assert.Equal(t, 4, sink.count)
+	sink = digitSum{}
+	assert.NoError(t, n.Drive(&sink, 5, 1000))
+	assert.Zero(t, sink.count)
+	assert.True(t, sink.flushed)
+}
+
+func TestDriveFlushError(t *testing.T) {
+	err := errors.New("flush failed")
+	sink := digitSum{err: err}
+	assert.Equal(t, err, Sqrt(2).Drive(&sink, 0, 10))
+}
@@ -8,9 +8,10 @@
 // number of digits. A *FiniteNumber can be used anywhere a Number type
 // is expected but not the other way around.
 //
-// A Sequence is a view of a contiguous subset of digits of a Number.
-// For example, A Sequence could represent everything past the 1000th digit
-// of the square root of 3. Because Sequences are views, they are cheap to
+// A Sequence is a view of a subset of digits of a Number. For example, A
+// Sequence could represent everything past the 1000th digit of the square
+// root of 3. Most Sequences are contiguous, but views such as those from
+// Stride can have gaps. Because Sequences are views, they are cheap to
 // create. Note that Number and *FiniteNumber can be used anywhere a Sequence
 // type is expected. A Sequence can be either infinite or finite in length.
 //
@@ -26,14 +27,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"math"
 	"math/big"
+	"strings"
+)
+
+const (
+	kCeilMaxZeros = 10000
 )
 
 var (
+	// zeroNumber is a shared 0 for comparisons. Never return it to
+	// callers because UnmarshalText and UnmarshalBinary overwrite their
+	// receiver. Return &FiniteNumber{} instead.
 	zeroNumber = &FiniteNumber{}
 )
 
+// ErrUndetermined indicates that a result could not be determined without
+// examining more digits than allowed.
+var ErrUndetermined = errors.New("sqrt: result undetermined within digit budget")
+
+// ErrExponentRange indicates that the exponent of a Number does not fit in
+// the type that a conversion needs.
+var ErrExponentRange = errors.New("sqrt: exponent out of range")
+
 var (
 	_ FiniteSequence = zeroNumber
 	_ Number         = zeroNumber
@@ -83,11 +102,72 @@ type Number interface {
 	// returns -1. If posit is negative, At returns -1.
 	At(posit int) int
 
+	// AtMany returns the significant digits of this Number at each of the
+	// given 0 based positions. The returned slice has the same length as
+	// positions and contains -1 wherever At would return -1. AtMany waits
+	// for the computation of the needed digits only once and reads all of
+	// them together, so it is faster than calling At for each position.
+	AtMany(positions []int) []int
+
 	// WithSignificant returns a view of this Number that has no more than
 	// limit significant digits. WithSignificant rounds the returned value
 	// down toward zero. WithSignificant panics if limit is negative.
 	WithSignificant(limit int) *FiniteNumber
 
+	// Enclosure returns lo and hi such that lo <= this Number <= hi. lo is
+	// this Number truncated to sigDigits significant digits, the same as
+	// WithSignificant returns. If this Number has no more than sigDigits
+	// significant digits, hi is the same as lo; otherwise hi is lo plus
+	// one unit in the sigDigits place. Enclosure panics if sigDigits is
+	// negative.
+	Enclosure(sigDigits int) (lo, hi *FiniteNumber)
+
+	// Round returns this Number rounded to sigDigits significant digits
+	// using round half to even. When the digit after the last kept digit
+	// is 5, Round examines as many following digits as it needs to
+	// determine whether this Number is exactly halfway between the two
+	// candidates. Round panics if sigDigits is negative.
+	Round(sigDigits int) *FiniteNumber
+
+	// RoundToPlaces works like Round except that it rounds this Number to
+	// places digits after the decimal point. places may be negative; for
+	// instance, a places of -2 rounds to the nearest hundred.
+	RoundToPlaces(places int) *FiniteNumber
+
+	// Floor returns the largest integer less than or equal to this Number.
+	Floor() *big.Int
+
+	// Ceil returns the smallest integer greater than or equal to this
+	// Number. Ceil has to determine whether all the digits after the
+	// decimal point are zero. Ceil returns ErrUndetermined if this Number
+	// has infinitely many digits, is not known to be periodic, and has
+	// more than 10,000 zero digits immediately after the decimal point.
+	Ceil() (*big.Int, error)
+
+	// IntegerPart returns a view of the digits of this Number that come
+	// before the decimal point, that is the digits at positions less than
+	// Exponent(). Like other views, IntegerPart keeps the 0 based positions
+	// of the digits. If this Number has fewer than Exponent() digits, the
+	// trailing zeros of the integer part are not in the returned view.
+	// If Exponent() is not positive, the returned view is empty.
+	IntegerPart() FiniteSequence
+
+	// FractionalPart returns a view of the digits of this Number that come
+	// after the decimal point, that is the digits at positions greater
+	// than or equal to Exponent(). Like other views, FractionalPart keeps
+	// the 0 based positions of the digits. If Exponent() is negative, the
+	// leading zeros after the decimal point are not in the returned view.
+	FractionalPart() Sequence
+
+	// ByDecimalPlace returns a view of the digits of this Number indexed
+	// by decimal place rather than by 0 based position. Index 0 is the
+	// units digit, index 1 is the first digit after the decimal point,
+	// index 2 is the second, and so on. Index -1 is the tens digit, index
+	// -2 is the hundreds digit, and so on, so indexes are negative for
+	// Numbers of 10 or more. The returned view is a FiniteSequence if
+	// this Number is a FiniteNumber.
+	ByDecimalPlace() Sequence
+
 	// Exponent returns the exponent of this Number.
 	Exponent() int
 
@@ -95,73 +175,290 @@ type Number interface {
 	// verbs work in the usual way except that they always round down.
 	// Because Number can have an infinite number of digits, g with no
 	// precision shows a max of 16 significant digits. Format supports
-	// width, precision, and the '-' flag for left justification. The v
-	// verb is an alias for g.
+	// width, precision, the '-' flag for left justification, the '0' flag
+	// for padding with leading zeros, and the '+' and ' ' flags for
+	// printing a leading plus sign or space. The v
+	// verb is an alias for g. The x and X verbs print this Number in base
+	// 16, and the b verb prints this Number in base 2. For x, X, and b,
+	// precision is the number of digits after the point and defaults to 6.
+	// The d verb prints only the digits before the decimal point, or 0 if
+	// there are none, and ignores precision. With the '#' flag, Format
+	// prints "..." at the end, after any exponent, if this Number has more
+	// digits than it printed. The exceptions are %#v which prints what
+	// GoString returns and %#x, %#X, and %#b which add a 0x, 0X, or 0b
+	// prefix as they do for integers in package fmt.
 	Format(state fmt.State, verb rune)
 
 	// String returns the decimal representation of this Number using %g.
+	// To show a different number of digits by default, use FormatWith with
+	// FormatOptions.DefaultPrecision.
 	String() string
 
+	// AppendFormat appends this Number formatted according to verb and
+	// precision to buf and returns the extended buffer. verb can be any
+	// verb that Format supports. A negative precision means the default
+	// precision for verb. AppendFormat avoids the allocations that fmt
+	// makes, so it is suited to printing many Numbers.
+	AppendFormat(buf []byte, verb rune, precision int) []byte
+
+	// FormatWith returns this Number formatted according to opts. Unlike
+	// Format, FormatWith takes the number of significant digits and the
+	// notation from opts rather than from a verb and precision.
+	FormatWith(opts FormatOptions) string
+
+	// WriteDigits writes this Number to w in fixed-point notation showing
+	// no more than count significant digits. WriteDigits streams the
+	// digits as it computes them, so it needs little memory even when
+	// count is large. If count is less than Exponent(), the integer part
+	// ends in zeros. WriteDigits returns the number of bytes written and
+	// the first error w returned. WriteDigits panics if count is negative.
+	WriteDigits(w io.Writer, count int) (int, error)
+
+	// LaTeX returns this Number in scientific notation for LaTeX with one
+	// digit before the decimal point, for example 1.41421\times10^{5}.
+	// LaTeX shows no more than sigDigits significant digits, always at
+	// least one, and rounds down. If the power of 10 is 0, LaTeX leaves
+	// it out.
+	LaTeX(sigDigits int) string
+
+	// MathML works like LaTeX except that it returns a MathML math
+	// element.
+	MathML(sigDigits int) string
+
 	// IsZero returns true if this Number is zero.
 	IsZero() bool
 
+	// Periodic returns the exact decimal representation of this Number
+	// with its repeating digits enclosed in parentheses, for example
+	// "10.2(0034)". Only Numbers created with repeating digits, such as
+	// with NewNumberForTesting, are known to be periodic. For a
+	// *FiniteNumber, Periodic returns the same as Exact. For any other
+	// Number, Periodic returns the empty string.
+	Periodic() string
+
+	// AsRat returns the exact value of this Number as a big.Rat along with
+	// true if this Number is known to have a finite number of digits or is
+	// known to be periodic. Otherwise AsRat returns nil, false. For a
+	// *FiniteNumber, AsRat always succeeds. See IsTerminating and Periodic.
+	AsRat() (*big.Rat, bool)
+
+	// Rat returns the exact value of the first sigDigits significant
+	// digits of this Number as a big.Rat. Rat panics if sigDigits is
+	// negative.
+	Rat(sigDigits int) *big.Rat
+
+	// BigFloat returns this Number as a big.Float with prec bits of
+	// precision correctly rounded to nearest even. If prec is 0, BigFloat
+	// uses 64 bits of precision.
+	BigFloat(prec uint) *big.Float
+
+	// Export returns the first maxDigits significant digits of this Number
+	// as values between 0 and 9 along with the exponent. exhausted is true
+	// if digits holds every digit of this Number. Import reverses Export.
+	// Export panics if maxDigits is negative.
+	Export(maxDigits int) (digits []byte, exp int, exhausted bool)
+
+	// Drive sends each digit of this Number from position start up to but
+	// not including position end to sink in order. Drive stops early if
+	// sink.Consume returns false. Drive returns what sink.Flush returns.
+	Drive(sink DigitSink, start, end int) error
+
+	// Decimal returns the first maxDigits significant digits of this
+	// Number as a Decimal for localized formatting. If the exponent of
+	// this Number does not fit in the int32 that Decimal.Exp is, Decimal
+	// returns ErrExponentRange. Decimal panics if maxDigits is negative.
+	Decimal(maxDigits int) (Decimal, error)
+
+	// IsTerminating returns true if this Number is known to have a finite
+	// number of digits. IsTerminating always returns true for a
+	// *FiniteNumber. The Sqrt and CubeRoot family of functions return a
+	// *FiniteNumber whenever the root they compute has a finite number of
+	// digits. For any other Number, IsTerminating returns true only once
+	// all of its digits have been computed. IsTerminating never computes
+	// any digits.
+	IsTerminating() bool
+
+	// CopyDigits copies the digits of this Number starting at the 0 based
+	// position start into dst and returns how many digits it copied.
+	// CopyDigits copies fewer than len(dst) digits only if this Number
+	// runs out of digits. CopyDigits panics if start is negative.
+	CopyDigits(dst []int8, start int) int
+
+	// BackwardFrom returns the 0 based position and value of each digit
+	// in this Number from position end - 1 down to position 0. It works
+	// like WithEnd(end).Backward() without creating a view.
+	BackwardFrom(end int) iter.Seq2[int, int]
+
+	// Cursor returns a new Cursor positioned at the first digit of this
+	// Number.
+	Cursor() *Cursor
+
+	// Snapshot returns a FiniteNumber with the digits of this Number that
+	// are already computed. Snapshot never computes any digits, and the
+	// returned FiniteNumber does not share memory with this Number.
+	Snapshot() *FiniteNumber
+
+	// DigitsPrefix returns the first count digits of this Number, or all
+	// of them if this Number has fewer, computing digits as needed and
+	// blocking until they exist. The returned slice shares memory with
+	// this Number, so callers must not modify it. DigitsPrefix panics if
+	// count is negative.
+	DigitsPrefix(count int) []int8
+
 	// NumComputed returns the number of computed digits in this Number.
 	// If this Number is a FiniteNumber, NumComputed will never return more
-	// than the number of significant digits.
+	// than the number of significant digits. NumComputed never computes
+	// any digits, so it is suitable for monitoring the progress of a long
+	// computation from another goroutine.
 	NumComputed() int
 
+	// KnownDigits returns how many significant digits of this Number are
+	// already known. Like NumComputed, which it equals, KnownDigits never
+	// computes any digits, so it is useful for sizing a Snapshot.
+	KnownDigits() int
+
 	withExponent(e int) Number
+	impl() *numberPart
 }
 
 // Sqrt returns the square root of radican. Sqrt panics if radican is
 // negative.
 func Sqrt(radican int64) Number {
-	return nRootFrac(big.NewInt(radican), one, newSqrtManager)
+	return nRootFrac(big.NewInt(radican), one, 2)
 }
 
 // SqrtRat returns the square root of num / denom. denom must be positive,
 // and num must be non-negative or else SqrtRat panics.
 func SqrtRat(num, denom int64) Number {
-	return nRootFrac(big.NewInt(num), big.NewInt(denom), newSqrtManager)
+	return nRootFrac(big.NewInt(num), big.NewInt(denom), 2)
 }
 
 // SqrtBigInt returns the square root of radican. SqrtBigInt panics if
 // radican is negative.
 func SqrtBigInt(radican *big.Int) Number {
-	return nRootFrac(radican, one, newSqrtManager)
+	return nRootFrac(radican, one, 2)
 }
 
 // SqrtBigRat returns the square root of radican. The denominator of radican
 // must be positive, and the numerator must be non-negative or else SqrtBigRat
 // panics.
 func SqrtBigRat(radican *big.Rat) Number {
-	return nRootFrac(radican.Num(), radican.Denom(), newSqrtManager)
+	return nRootFrac(radican.Num(), radican.Denom(), 2)
+}
+
+// SqrtNewton works like SqrtBigRat except that the returned Number
+// computes its digits with Newton's method, doubling the number of
+// digits it has each time it runs out. Computing d digits this way takes
+// time close to that of a few multiplications of d digit numbers, so
+// SqrtNewton is much faster than SqrtBigRat when computing hundreds of
+// thousands of digits or more. However, SqrtNewton computes digits in
+// ever larger blocks, so SqrtBigRat is a better choice when only a few
+// thousand digits are needed.
+func SqrtNewton(radican *big.Rat) Number {
+	num, denom := radican.Num(), radican.Denom()
+	checkNumDenom(num, denom)
+	if num.Sign() == 0 {
+		return &FiniteNumber{}
+	}
+	if result, ok := exactRoot(num, denom, 2); ok {
+		return result
+	}
+	return newNumber(newNewtonSqrtGenerator(num, denom).Generate())
+}
+
+// InvSqrt returns 1 / the square root of radican. InvSqrt computes the
+// digits directly as the square root of 1 / radican, so it needs no
+// division. InvSqrt panics if radican is not positive.
+func InvSqrt(radican int64) Number {
+	if radican <= 0 {
+		panic("radican must be positive")
+	}
+	return nRootFrac(one, big.NewInt(radican), 2)
+}
+
+// SqrtScaled returns the square root of m * 10^pow10. SqrtScaled never
+// computes 10^pow10, so it is efficient even when pow10 is huge. SqrtScaled
+// panics if m is negative.
+func SqrtScaled(m *big.Int, pow10 int) Number {
+	half, odd := pow10/2, pow10%2
+	if odd < 0 {
+		half, odd = half-1, odd+2
+	}
+	radican := m
+	if odd == 1 {
+		radican = new(big.Int).Mul(m, ten)
+	}
+	result := SqrtBigInt(radican)
+	return result.withExponent(result.Exponent() + half)
+}
+
+// Hypot returns the square root of a*a + b*b. Unlike computing a*a + b*b
+// with int64, Hypot never overflows.
+func Hypot(a, b int64) Number {
+	return HypotBigRat(new(big.Rat).SetInt64(a), new(big.Rat).SetInt64(b))
+}
+
+// HypotBigRat returns the square root of a*a + b*b.
+func HypotBigRat(a, b *big.Rat) Number {
+	var sum, bSquared big.Rat
+	sum.Mul(a, a)
+	sum.Add(&sum, bSquared.Mul(b, b))
+	return SqrtBigRat(&sum)
 }
 
 // CubeRoot returns the cube root of radican. CubeRoot panics if radican is
 // negative as Number can only hold positive results.
 func CubeRoot(radican int64) Number {
-	return nRootFrac(big.NewInt(radican), one, newCubeRootManager)
+	return nRootFrac(big.NewInt(radican), one, 3)
 }
 
 // CubeRootRat returns the cube root of num / denom. Because Number can only
 // hold positive results, denom must be positive, and num must be non-negative
 // or else CubeRootRat panics.
 func CubeRootRat(num, denom int64) Number {
-	return nRootFrac(big.NewInt(num), big.NewInt(denom), newCubeRootManager)
+	return nRootFrac(big.NewInt(num), big.NewInt(denom), 3)
 }
 
 // CubeRootBigInt returns the cube root of radican. CubeRootBigInt panics if
 // radican is negative as Number can only hold positive results.
 func CubeRootBigInt(radican *big.Int) Number {
-	return nRootFrac(radican, one, newCubeRootManager)
+	return nRootFrac(radican, one, 3)
 }
 
 // CubeRootBigRat returns the cube root of radican. Because Number can only
 // hold positive results, the denominator of radican must be positive, and the
 // numerator must be non-negative or else CubeRootBigRat panics.
 func CubeRootBigRat(radican *big.Rat) Number {
-	return nRootFrac(radican.Num(), radican.Denom(), newCubeRootManager)
+	return nRootFrac(radican.Num(), radican.Denom(), 3)
+}
+
+// NRootBigRat returns the nth root of radican. NRootBigRat(radican, 2) is
+// the same as SqrtBigRat(radican), and NRootBigRat(radican, 3) is the same
+// as CubeRootBigRat(radican). Because Number can only hold positive results,
+// NRootBigRat panics if n is not positive, if the denominator of radican is
+// not positive, or if the numerator of radican is negative.
+func NRootBigRat(radican *big.Rat, n int) Number {
+	if n <= 0 {
+		panic("n must be positive")
+	}
+	return nRootFrac(radican.Num(), radican.Denom(), n)
+}
+
+// GeometricMean returns the geometric mean of values, that is the nth root
+// of the product of values where n is the length of values. GeometricMean
+// panics if values is empty or if any of the values are negative.
+func GeometricMean(values []*big.Rat) Number {
+	if len(values) == 0 {
+		panic("values must be non-empty")
+	}
+	product := big.NewRat(1, 1)
+	for _, value := range values {
+		if value.Sign() < 0 {
+			panic("values must be non-negative")
+		}
+		product.Mul(product, value)
+	}
+	return nRootFrac(product.Num(), product.Denom(), len(values))
 }
 
 // NewNumberForTesting creates an arbitrary Number for testing. fixed are
@@ -175,7 +472,7 @@ func CubeRootBigRat(radican *big.Rat) Number {
 // and 1.0 exclusive.
 func NewNumberForTesting(fixed, repeating []int, exp int) (Number, error) {
 	if len(fixed) == 0 && len(repeating) == 0 {
-		return zeroNumber, nil
+		return &FiniteNumber{}, nil
 	}
 	if !validDigits(fixed) || !validDigits(repeating) {
 		return nil, errors.New("NewNumberForTesting: digits must be between 0 and 9")
@@ -188,7 +485,10 @@ func NewNumberForTesting(fixed, repeating []int, exp int) (Number, error) {
 	if len(repeating) == 0 {
 		return newFiniteNumber(gen.Generate()), nil
 	}
-	return newNumber(gen.Generate()), nil
+	return &number{
+		numberPart: newnumberPart(gen.Generate()),
+		cycle:      newCycle(fixed, repeating),
+	}, nil
 }
 
 // NewNumber returns a new Number based on g. Although g is expected to
@@ -200,11 +500,29 @@ func NewNumber(g Generator) Number {
 	digits, exp := g.Generate()
 	first := digits()
 	if first == 0 || digitOutOfRange(first) {
-		return zeroNumber
+		return &FiniteNumber{}
 	}
 	return newNumber(firstAndThen(first, digits), exp)
 }
 
+// BatchAt returns the significant digits of n at each of the given 0 based
+// positions. The returned slice has the same length as positions and
+// contains -1 wherever n.At would return -1. BatchAt is faster than calling
+// At once for each position because it waits for the computation of the
+// needed digits only once. BatchAt is the same as n.AtMany(positions).
+func BatchAt(n Number, positions []int) []int {
+	return n.AtMany(positions)
+}
+
+// IsFinite returns true if n is known to have a finite number of digits.
+// IsFinite never blocks and never computes any digits of n, so for a
+// Number that is not a *FiniteNumber, such as one from NewNumber, it
+// returns true only once all the digits of n have been computed. IsFinite
+// is the same as n.IsTerminating().
+func IsFinite(n Number) bool {
+	return n.IsTerminating()
+}
+
 // FiniteNumber is a Number with a finite number of digits. FiniteNumber
 // implements both Number and FiniteSequence. The zero value for FiniteNumber
 // is 0.
@@ -251,6 +569,11 @@ func (n *FiniteNumber) At(posit int) int {
 	return n.numberPart.At(posit)
 }
 
+// AtMany comes from the Number interface.
+func (n *FiniteNumber) AtMany(positions []int) []int {
+	return n.numberPart.AtMany(positions)
+}
+
 // WithSignificant comes from the Number interface.
 func (n *FiniteNumber) WithSignificant(limit int) *FiniteNumber {
 	if limit < 0 {
@@ -259,6 +582,46 @@ func (n *FiniteNumber) WithSignificant(limit int) *FiniteNumber {
 	return n.withEnd(limit)
 }
 
+// Enclosure comes from the Number interface.
+func (n *FiniteNumber) Enclosure(sigDigits int) (lo, hi *FiniteNumber) {
+	return enclosure(n, sigDigits)
+}
+
+// Round comes from the Number interface.
+func (n *FiniteNumber) Round(sigDigits int) *FiniteNumber {
+	return round(n, sigDigits, RoundHalfEven)
+}
+
+// RoundToPlaces comes from the Number interface.
+func (n *FiniteNumber) RoundToPlaces(places int) *FiniteNumber {
+	return roundToPlaces(n, places, RoundHalfEven)
+}
+
+// Floor comes from the Number interface.
+func (n *FiniteNumber) Floor() *big.Int {
+	return floor(n)
+}
+
+// Ceil comes from the Number interface.
+func (n *FiniteNumber) Ceil() (*big.Int, error) {
+	return ceil(n)
+}
+
+// IntegerPart comes from the Number interface.
+func (n *FiniteNumber) IntegerPart() FiniteSequence {
+	return n.WithEnd(max(n.Exponent(), 0))
+}
+
+// FractionalPart comes from the Number interface.
+func (n *FiniteNumber) FractionalPart() Sequence {
+	return n.WithStart(n.Exponent())
+}
+
+// ByDecimalPlace comes from the Number interface.
+func (n *FiniteNumber) ByDecimalPlace() Sequence {
+	return byDecimalPlace(n)
+}
+
 // Exponent comes from the Number interface.
 func (n *FiniteNumber) Exponent() int {
 	return n.numberPart.Exponent()
@@ -266,6 +629,10 @@ func (n *FiniteNumber) Exponent() int {
 
 // Format comes from the Number interface.
 func (n *FiniteNumber) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		io.WriteString(state, n.GoString())
+		return
+	}
 	n.numberPart.Format(state, verb)
 }
 
@@ -275,6 +642,80 @@ func (n *FiniteNumber) Exact() string {
 	return n.numberPart.Exact()
 }
 
+// Shortest returns the shortest exact representation of n. Like strconv
+// does for floats, Shortest chooses between fixed-point notation such as
+// "0.0015" and scientific notation with one digit before the decimal
+// point such as "1.5e-03", whichever is shorter. Shortest prefers
+// fixed-point notation when both are the same length.
+func (n *FiniteNumber) Shortest() string {
+	digits := strings.TrimRight(AsString(n), "0")
+	if digits == "" {
+		return "0"
+	}
+	exp := n.Exponent()
+	var fixed string
+	switch {
+	case exp >= len(digits):
+		fixed = digits + strings.Repeat("0", exp-len(digits))
+	case exp > 0:
+		fixed = digits[:exp] + "." + digits[exp:]
+	default:
+		fixed = "0." + strings.Repeat("0", -exp) + digits
+	}
+	sci := []byte(digits[:1])
+	if len(digits) > 1 {
+		sci = append(append(sci, '.'), digits[1:]...)
+	}
+	sci = appendExponent(append(sci, 'e'), exp-1, 2)
+	if len(sci) < len(fixed) {
+		return string(sci)
+	}
+	return fixed
+}
+
+// Periodic comes from the Number interface.
+func (n *FiniteNumber) Periodic() string {
+	return n.Exact()
+}
+
+// AsRat comes from the Number interface.
+func (n *FiniteNumber) AsRat() (*big.Rat, bool) {
+	return exactRat(&n.numberPart), true
+}
+
+// ExactRat returns the exact value of this FiniteNumber as a big.Rat.
+// ExactRat is the same as AsRat except that it doesn't return a bool since
+// it always succeeds.
+func (n *FiniteNumber) ExactRat() *big.Rat {
+	return exactRat(&n.numberPart)
+}
+
+// AppendFormat comes from the Number interface.
+func (n *FiniteNumber) AppendFormat(
+	buf []byte, verb rune, precision int) []byte {
+	return n.numberPart.AppendFormat(buf, verb, precision)
+}
+
+// FormatWith comes from the Number interface.
+func (n *FiniteNumber) FormatWith(opts FormatOptions) string {
+	return formatWith(n, opts)
+}
+
+// WriteDigits comes from the Number interface.
+func (n *FiniteNumber) WriteDigits(w io.Writer, count int) (int, error) {
+	return n.numberPart.WriteDigits(w, count)
+}
+
+// LaTeX comes from the Number interface.
+func (n *FiniteNumber) LaTeX(sigDigits int) string {
+	return n.numberPart.LaTeX(sigDigits)
+}
+
+// MathML comes from the Number interface.
+func (n *FiniteNumber) MathML(sigDigits int) string {
+	return n.numberPart.MathML(sigDigits)
+}
+
 // String comes from the Number interface.
 func (n *FiniteNumber) String() string {
 	return n.numberPart.String()
@@ -285,6 +726,37 @@ func (n *FiniteNumber) IsZero() bool {
 	return n.numberPart.IsZero()
 }
 
+// Rat comes from the Number interface.
+func (n *FiniteNumber) Rat(sigDigits int) *big.Rat {
+	return exactRat(&n.WithSignificant(sigDigits).numberPart)
+}
+
+// Drive comes from the Number interface.
+func (n *FiniteNumber) Drive(sink DigitSink, start, end int) error {
+	return n.numberPart.Drive(sink, start, end)
+}
+
+// Decimal comes from the Number interface.
+func (n *FiniteNumber) Decimal(maxDigits int) (Decimal, error) {
+	return decimal(n, maxDigits)
+}
+
+// Export comes from the Number interface.
+func (n *FiniteNumber) Export(maxDigits int) (
+	digits []byte, exp int, exhausted bool) {
+	return export(n, maxDigits)
+}
+
+// BigFloat comes from the Number interface.
+func (n *FiniteNumber) BigFloat(prec uint) *big.Float {
+	return SetBigFloat(new(big.Float).SetPrec(prec), n)
+}
+
+// IsTerminating comes from the Number interface.
+func (n *FiniteNumber) IsTerminating() bool {
+	return true
+}
+
 // All comes from the Sequence interface.
 func (n *FiniteNumber) All() iter.Seq2[int, int] {
 	return n.numberPart.All()
@@ -295,26 +767,118 @@ func (n *FiniteNumber) AllInRange(start, end int) iter.Seq2[int, int] {
 	return n.numberPart.AllInRange(start, end)
 }
 
+// AllFrom comes from the Sequence interface.
+func (n *FiniteNumber) AllFrom(
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	return n.numberPart.AllFrom(token)
+}
+
 // Values comes from the Sequence interface.
 func (n *FiniteNumber) Values() iter.Seq[int] {
 	return n.numberPart.Values()
 }
 
+// Chunks comes from the Sequence interface.
+func (n *FiniteNumber) Chunks(size int) iter.Seq2[int, []int8] {
+	return n.numberPart.Chunks(size)
+}
+
+// ValuesBase comes from the Sequence interface.
+func (n *FiniteNumber) ValuesBase(pow int) iter.Seq[int] {
+	return n.numberPart.ValuesBase(pow)
+}
+
+// Stride comes from the Sequence interface.
+func (n *FiniteNumber) Stride(k int) Sequence {
+	return &finiteStridedSequence{newStridedPart(n.mantissa, 0, k)}
+}
+
+// PackedDigits comes from the Sequence interface.
+func (n *FiniteNumber) PackedDigits(max int) []byte {
+	return n.numberPart.PackedDigits(max)
+}
+
 // PrimeToStart comes from the Sequence interface.
 func (n *FiniteNumber) PrimeToStart(ctx context.Context) error {
 	return n.numberPart.PrimeToStart(ctx)
 }
 
+// CopyDigits comes from the Number interface.
+func (n *FiniteNumber) CopyDigits(dst []int8, start int) int {
+	return n.numberPart.CopyDigits(dst, start)
+}
+
+// BackwardFrom comes from the Number interface.
+func (n *FiniteNumber) BackwardFrom(end int) iter.Seq2[int, int] {
+	return n.numberPart.BackwardFrom(end)
+}
+
+// Cursor comes from the Number interface.
+func (n *FiniteNumber) Cursor() *Cursor {
+	return n.numberPart.Cursor()
+}
+
+// Snapshot comes from the Number interface.
+func (n *FiniteNumber) Snapshot() *FiniteNumber {
+	return n.numberPart.Snapshot()
+}
+
+// DigitsPrefix comes from the Number interface.
+func (n *FiniteNumber) DigitsPrefix(count int) []int8 {
+	return n.numberPart.DigitsPrefix(count)
+}
+
 // NumComputed comes from the Number interface.
 func (n *FiniteNumber) NumComputed() int {
 	return n.numberPart.NumComputed()
 }
 
+// KnownDigits comes from the Number interface.
+func (n *FiniteNumber) KnownDigits() int {
+	return n.numberPart.KnownDigits()
+}
+
 // Backward comes from the FiniteSequence interface.
 func (n *FiniteNumber) Backward() iter.Seq2[int, int] {
 	return n.backward()
 }
 
+// Start comes from the Sequence interface.
+func (n *FiniteNumber) Start() int {
+	return n.numberPart.Start()
+}
+
+// IsEmpty comes from the FiniteSequence interface.
+func (n *FiniteNumber) IsEmpty() bool {
+	return n.IsZero()
+}
+
+// End comes from the FiniteSequence interface.
+func (n *FiniteNumber) End() (int, bool) {
+	return n.end()
+}
+
+// AllInRangeBackward comes from the FiniteSequence interface.
+func (n *FiniteNumber) AllInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return n.allInRangeBackward(start, end)
+}
+
+// Reversed comes from the FiniteSequence interface.
+func (n *FiniteNumber) Reversed() FiniteSequence {
+	return reversed(n)
+}
+
+// Len comes from the FiniteSequence interface.
+func (n *FiniteNumber) Len() int {
+	return n.mantissa.Len(0)
+}
+
+// WriteTo comes from the FiniteSequence interface.
+func (n *FiniteNumber) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, n)
+}
+
 // PrimeToEnd comes from the FiniteSequence interface.
 func (n *FiniteNumber) PrimeToEnd(ctx context.Context) error {
 	return n.primeToEnd(ctx)
@@ -334,7 +898,7 @@ func (n *FiniteNumber) withEnd(end int) *FiniteNumber {
 		return n
 	}
 	if result.IsZero() {
-		return zeroNumber
+		return &FiniteNumber{}
 	}
 	return &FiniteNumber{result}
 }
@@ -342,19 +906,101 @@ func (n *FiniteNumber) withEnd(end int) *FiniteNumber {
 func (n *FiniteNumber) private() {
 }
 
-func nRootFrac(
-	num, denom *big.Int, newManager func() rootManager) Number {
+func nRootFrac(num, denom *big.Int, n int) Number {
 	checkNumDenom(num, denom)
 	if num.Sign() == 0 {
-		return zeroNumber
+		return &FiniteNumber{}
 	}
-	return newNumber(newNRootGenerator(num, denom, newManager).Generate())
+	if result, ok := exactRoot(num, denom, n); ok {
+		return result
+	}
+	return newNumber(newRootGenerator(num, denom, n).Generate())
+}
+
+// exactRoot returns the nth root of num / denom as a FiniteNumber if that
+// root has a finite number of digits. num and denom must be positive.
+func exactRoot(num, denom *big.Int, n int) (*FiniteNumber, bool) {
+	var gcd, p, q big.Int
+	gcd.GCD(nil, nil, num, denom)
+	p.Quo(num, &gcd)
+	q.Quo(denom, &gcd)
+	rootP, ok := intRoot(&p, n)
+	if !ok {
+		return nil, false
+	}
+	rootQ, ok := intRoot(&q, n)
+	if !ok {
+		return nil, false
+	}
+
+	// rootP / rootQ has a finite number of digits only if the only prime
+	// factors of rootQ are 2 and 5.
+	var rest, quo, rem big.Int
+	rest.Set(rootQ)
+	fractionDigits := 0
+	for _, factor := range []*big.Int{two, five} {
+		count := 0
+		for {
+			quo.QuoRem(&rest, factor, &rem)
+			if rem.Sign() != 0 {
+				break
+			}
+			rest.Set(&quo)
+			count++
+		}
+		fractionDigits = max(fractionDigits, count)
+	}
+	if rest.Cmp(one) != 0 {
+		return nil, false
+	}
+	rootP.Mul(rootP, pow10(fractionDigits))
+	rootP.Quo(rootP, rootQ)
+	digits := rootP.String()
+	return finiteNumberFromDigits(digits, len(digits)-fractionDigits), true
+}
+
+// finiteNumberFromDigits returns the FiniteNumber with the given mantissa
+// digits and exponent. The first digit in digits must be non-zero. Trailing
+// zeros in digits are ignored. If digits is empty or contains only zeros,
+// finiteNumberFromDigits returns zero.
+func finiteNumberFromDigits(digits string, exp int) *FiniteNumber {
+	digits = strings.TrimRight(digits, "0")
+	if digits == "" {
+		return &FiniteNumber{}
+	}
+	fixed := make([]int, len(digits))
+	for i := range digits {
+		fixed[i] = int(digits[i] - '0')
+	}
+	return newFiniteNumber(newRepeatingGenerator(fixed, nil, exp).Generate())
+}
+
+// intRoot returns the floor of the nth root of x and whether that root is
+// exact. x and n must be positive.
+func intRoot(x *big.Int, n int) (*big.Int, bool) {
+	bigN := big.NewInt(int64(n))
+	nMinus1 := big.NewInt(int64(n - 1))
+	root := new(big.Int).Lsh(one, uint((x.BitLen()+n-1)/n))
+	var next, temp big.Int
+	for {
+
+		// Newton's method: next = ((n-1)*root + x / root^(n-1)) / n
+		temp.Exp(root, nMinus1, nil)
+		next.Quo(x, &temp)
+		next.Add(&next, temp.Mul(nMinus1, root))
+		next.Quo(&next, bigN)
+		if next.Cmp(root) >= 0 {
+			break
+		}
+		root.Set(&next)
+	}
+	return root, temp.Exp(root, bigN, nil).Cmp(x) == 0
 }
 
 // newNumber returns a new number. The first digit that digits generates
 // must be between 1 and 9.
 func newNumber(digits func() int, exp int) Number {
-	return &number{newnumberPart(digits, exp)}
+	return &number{numberPart: newnumberPart(digits, exp)}
 }
 
 func newFiniteNumber(digits func() int, exp int) *FiniteNumber {
@@ -376,6 +1022,17 @@ func checkNumDenom(num, denom *big.Int) {
 
 type number struct {
 	numberPart
+
+	// cycle is nil if the repeating digits of this number are unknown.
+	cycle *cycle
+
+	// logOf is the Number whose natural logarithm this number is or nil
+	// if this number did not come from Log.
+	logOf Number
+
+	// expOf is the Number that e is raised to for this number or nil if
+	// this number did not come from Exp.
+	expOf Number
 }
 
 func (n *number) WithStart(start int) Sequence {
@@ -397,20 +1054,107 @@ func (n *number) WithSignificant(limit int) *FiniteNumber {
 	return n.withEnd(limit)
 }
 
+func (n *number) Enclosure(sigDigits int) (lo, hi *FiniteNumber) {
+	return enclosure(n, sigDigits)
+}
+
+func (n *number) Round(sigDigits int) *FiniteNumber {
+	return round(n, sigDigits, RoundHalfEven)
+}
+
+func (n *number) RoundToPlaces(places int) *FiniteNumber {
+	return roundToPlaces(n, places, RoundHalfEven)
+}
+
+func (n *number) FormatWith(opts FormatOptions) string {
+	return formatWith(n, opts)
+}
+
+func (n *number) Floor() *big.Int {
+	return floor(n)
+}
+
+func (n *number) Ceil() (*big.Int, error) {
+	return ceil(n)
+}
+
+func (n *number) IntegerPart() FiniteSequence {
+	return n.WithEnd(max(n.Exponent(), 0))
+}
+
+func (n *number) FractionalPart() Sequence {
+	return n.WithStart(n.Exponent())
+}
+
+func (n *number) ByDecimalPlace() Sequence {
+	return byDecimalPlace(n)
+}
+
 func (n *number) withEnd(end int) *FiniteNumber {
 	result := n.numberPart.withEnd(end)
 	if result.IsZero() {
-		return zeroNumber
+		return &FiniteNumber{}
 	}
 	return &FiniteNumber{result}
 }
 
+func (n *number) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		io.WriteString(state, n.GoString())
+		return
+	}
+	n.numberPart.formatWithOptions(state, verb, formatOptions{source: n})
+}
+
+func (n *number) AppendFormat(buf []byte, verb rune, precision int) []byte {
+	return n.numberPart.appendFormatWithOptions(
+		buf, verb, precision, formatOptions{source: n})
+}
+
+func (n *number) Periodic() string {
+	if n.cycle == nil {
+		return ""
+	}
+	return n.cycle.String(&n.numberPart)
+}
+
+func (n *number) AsRat() (*big.Rat, bool) {
+	if n.cycle != nil {
+		return n.cycle.Rat(&n.numberPart), true
+	}
+	if n.IsTerminating() {
+		return exactRat(&n.numberPart), true
+	}
+	return nil, false
+}
+
+func (n *number) Rat(sigDigits int) *big.Rat {
+	return exactRat(&n.WithSignificant(sigDigits).numberPart)
+}
+
+func (n *number) Decimal(maxDigits int) (Decimal, error) {
+	return decimal(n, maxDigits)
+}
+
+func (n *number) Export(maxDigits int) (
+	digits []byte, exp int, exhausted bool) {
+	return export(n, maxDigits)
+}
+
+func (n *number) BigFloat(prec uint) *big.Float {
+	return SetBigFloat(new(big.Float).SetPrec(prec), n)
+}
+
+func (n *number) IsTerminating() bool {
+	return n.mantissa.Done()
+}
+
 func (n *number) withExponent(e int) Number {
 	result := n.numberPart.withExponent(e)
 	if result == n.numberPart {
 		return n
 	}
-	return &number{result}
+	return &number{numberPart: result, cycle: n.cycle}
 }
 
 func (n *number) private() {
@@ -435,3 +1179,180 @@ func validDigits(x []int) bool {
 	}
 	return true
 }
+
+func enclosure(n Number, sigDigits int) (lo, hi *FiniteNumber) {
+	lo = n.WithSignificant(sigDigits)
+	if n.At(sigDigits) == -1 {
+		return lo, lo
+	}
+	return lo, addUlp(lo, n.Exponent())
+}
+
+// round returns n rounded to sigDigits significant digits according to
+// mode.
+func round(n Number, sigDigits int, mode RoundingMode) *FiniteNumber {
+	result := n.WithSignificant(sigDigits)
+	if mode == RoundDown {
+		return result
+	}
+	digit := n.At(sigDigits)
+	if digit < 5 {
+		return result
+	}
+	if mode == RoundHalfEven && digit == 5 && allZerosFrom(n, sigDigits+1) {
+
+		// A tie, round to the even digit.
+		if sigDigits == 0 || n.At(sigDigits-1)%2 == 0 {
+			return result
+		}
+	}
+	return addUlp(result, n.Exponent())
+}
+
+// roundToPlaces returns n rounded to places digits after the decimal
+// point according to mode.
+func roundToPlaces(n Number, places int, mode RoundingMode) *FiniteNumber {
+	sigDigits := places + n.Exponent()
+	if sigDigits < 0 {
+		return &FiniteNumber{}
+	}
+	return round(n, sigDigits, mode)
+}
+
+func floor(n Number) *big.Int {
+	exp := n.Exponent()
+	if exp <= 0 {
+		return new(big.Int)
+	}
+	result, count := mantissaInt(n.WithSignificant(exp))
+	return result.Mul(result, pow10(exp-count))
+}
+
+func ceil(n Number) (*big.Int, error) {
+	result := floor(n)
+	allZeros, ok := zerosFrom(n, max(n.Exponent(), 0), kCeilMaxZeros)
+	if !ok {
+		return nil, ErrUndetermined
+	}
+	if !allZeros {
+		result.Add(result, one)
+	}
+	return result, nil
+}
+
+// allZerosFrom returns true if all the digits of n at posit and beyond are
+// zero. allZerosFrom returns as soon as it finds a non-zero digit. If n
+// has infinitely many digits that are all zero at posit and beyond,
+// allZerosFrom returns only if n is known to be periodic.
+func allZerosFrom(n Number, posit int) bool {
+	result, _ := zerosFrom(n, posit, math.MaxInt)
+	return result
+}
+
+// zerosFrom works like allZerosFrom except that it examines no more than
+// budget digits. If zerosFrom runs out of budget, it returns false, false.
+func zerosFrom(n Number, posit, budget int) (allZeros, ok bool) {
+	var c *cycle
+	if nn, isNumber := n.(*number); isNumber {
+		c = nn.cycle
+	}
+	for index, digit := range n.WithStart(posit).All() {
+		if index-posit >= budget {
+			return false, false
+		}
+		if digit != 0 {
+			return false, true
+		}
+
+		// A full cycle of zeros means the rest of the digits are zero.
+		if c != nil && index >= max(posit, c.start)+c.length-1 {
+			return true, true
+		}
+	}
+	return true, true
+}
+
+// addUlp returns x plus one unit in its last place where exp is the
+// exponent to use for x. The last place of x is the position of its last
+// digit including any trailing zeros.
+func addUlp(x *FiniteNumber, exp int) *FiniteNumber {
+	digits := []byte(AsString(x))
+	i := len(digits) - 1
+	for i >= 0 && digits[i] == '9' {
+		digits[i] = '0'
+		i--
+	}
+	if i < 0 {
+		return finiteNumberFromDigits("1", exp+1)
+	}
+	digits[i]++
+	return finiteNumberFromDigits(string(digits), exp)
+}
+
+// bracket returns lo and hi such that lo <= n <= hi. lo is n truncated to
+// sigDigits significant digits. hi equals lo if n has no more than
+// sigDigits significant digits; otherwise hi is lo plus one unit in the
+// last place.
+func bracket(n Number, sigDigits int) (lo, hi *big.Rat) {
+	mantissa, count := mantissaInt(n.WithSignificant(sigDigits))
+	lo = ratTimesPow10(mantissa, n.Exponent()-count)
+	if count < sigDigits {
+		return lo, lo
+	}
+	mantissa.Add(mantissa, one)
+	return lo, ratTimesPow10(mantissa, n.Exponent()-count)
+}
+
+// SetBigFloat sets dst to n rounded according to the precision and rounding
+// mode of dst and returns dst. If dst has 0 precision, SetBigFloat changes
+// it to 64. SetBigFloat lets callers reuse a big.Float instead of
+// allocating a new one with BigFloat.
+func SetBigFloat(dst *big.Float, n Number) *big.Float {
+	if dst.Prec() == 0 {
+		dst.SetPrec(64)
+	}
+	if r, ok := n.AsRat(); ok {
+		return dst.SetRat(r)
+	}
+
+	// n has infinitely many digits, so it lies strictly between lo and
+	// hi. Once lo and hi round to the same value, n rounds to it too.
+	var lof, hif big.Float
+	lof.SetPrec(dst.Prec()).SetMode(dst.Mode())
+	hif.SetPrec(dst.Prec()).SetMode(dst.Mode())
+	for sigDigits := kIntervalInitialPrecision; ; sigDigits *= 2 {
+		lo, hi := bracket(n, sigDigits)
+		if lof.SetRat(lo).Cmp(hif.SetRat(hi)) == 0 {
+			return dst.Set(&lof)
+		}
+	}
+}
+
+// exactRat returns the exact value of n which must have a finite number of
+// digits.
+func exactRat(n *numberPart) *big.Rat {
+	mantissa, count := mantissaInt(&FiniteNumber{*n})
+	return ratTimesPow10(mantissa, n.exponent-count)
+}
+
+// mantissaInt returns the digits of s as a big.Int along with the number
+// of digits in s.
+func mantissaInt(s FiniteSequence) (*big.Int, int) {
+	digits := AsString(s)
+	if digits == "" {
+		return new(big.Int), 0
+	}
+	result, _ := new(big.Int).SetString(digits, 10)
+	return result, len(digits)
+}
+
+func ratTimesPow10(x *big.Int, exp int) *big.Rat {
+	if exp >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(x, pow10(exp)))
+	}
+	return new(big.Rat).SetFrac(x, pow10(-exp))
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(exp)), nil)
+}
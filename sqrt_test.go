@@ -5,7 +5,9 @@ import (
 	"iter"
 	"math"
 	"math/big"
+	"runtime"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/keep94/itertools"
@@ -45,7 +47,7 @@ func Test3(t *testing.T) {
 func Test0(t *testing.T) {
 	n := Sqrt(0)
 	assert.Zero(t, *zeroNumber)
-	assert.Same(t, zeroNumber, n)
+	assert.Equal(t, zeroNumber, n)
 }
 
 func Test1(t *testing.T) {
@@ -103,6 +105,317 @@ func TestAllInRange(t *testing.T) {
 	assert.Empty(t, collect(iterator, 0))
 }
 
+func TestIsFinite(t *testing.T) {
+	assert.True(t, IsFinite(Sqrt(256)))
+	assert.True(t, IsFinite(SqrtRat(1, 4)))
+	assert.True(t, IsFinite(Sqrt(0)))
+	assert.True(t, IsFinite(Sqrt(2).WithSignificant(10)))
+	assert.False(t, IsFinite(Sqrt(2)))
+	assert.False(t, IsFinite(SqrtRat(1, 3)))
+	n := Sqrt(2)
+	assert.False(t, IsFinite(n))
+	assert.Zero(t, n.NumComputed())
+
+	// Agrees with IsTerminating once all the digits are computed.
+	g := NewNumber(SequenceGenerator(Sqrt(2).WithEnd(3), 1))
+	assert.False(t, IsFinite(g))
+	assert.Equal(t, -1, g.At(3))
+	assert.True(t, IsFinite(g))
+	assert.Equal(t, g.IsTerminating(), IsFinite(g))
+}
+
+func TestAsFiniteSequence(t *testing.T) {
+	fs, ok := AsFiniteSequence(Sqrt(2).WithStart(3).WithEnd(7))
+	assert.True(t, ok)
+	assert.Equal(t, "4213", AsString(fs))
+	fs, ok = AsFiniteSequence(Sqrt(256).WithStart(1))
+	assert.True(t, ok)
+	assert.Equal(t, "6", AsString(fs))
+	fs, ok = AsFiniteSequence(Sqrt(2).WithEnd(9).Stride(2))
+	assert.True(t, ok)
+	assert.Equal(t, 5, fs.Len())
+	fs, ok = AsFiniteSequence(Sqrt(2).WithStart(3))
+	assert.False(t, ok)
+	assert.Nil(t, fs)
+	_, ok = AsFiniteSequence(Sqrt(2))
+	assert.False(t, ok)
+}
+
+func TestZip(t *testing.T) {
+	var positions []int
+	var pairs [][2]int
+	for posit, pair := range Zip(Sqrt(2), Sqrt(3).WithStart(2).WithEnd(6)) {
+		positions = append(positions, posit)
+		pairs = append(pairs, pair)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, positions)
+	assert.Equal(t, [][2]int{{1, 3}, {4, 2}, {2, 0}, {1, 5}}, pairs)
+}
+
+func TestZipGaps(t *testing.T) {
+	var positions []int
+	for posit := range Zip(Sqrt(2).Stride(2), Sqrt(3).Stride(3)) {
+		positions = append(positions, posit)
+		if len(positions) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 6, 12}, positions)
+}
+
+func TestZipEmpty(t *testing.T) {
+	for range Zip(zeroNumber, Sqrt(2)) {
+		assert.Fail(t, "expected no pairs")
+	}
+	for range Zip(Sqrt(2), zeroNumber) {
+		assert.Fail(t, "expected no pairs")
+	}
+}
+
+func TestSequenceEqual(t *testing.T) {
+	n := Sqrt(2)
+	assert.True(t, SequenceEqual(n.WithEnd(10), Sqrt(2).WithEnd(10)))
+	assert.True(t, SequenceEqual(
+		n.WithStart(3).WithEnd(10), n.WithEnd(10).FiniteWithStart(3)))
+	assert.False(t, SequenceEqual(n.WithEnd(10), n.WithEnd(9)))
+	assert.False(t, SequenceEqual(n.WithEnd(9), n.WithEnd(10)))
+	assert.False(t, SequenceEqual(n.WithEnd(10), Sqrt(3).WithEnd(10)))
+
+	// Same digits at different positions
+	assert.False(t, SequenceEqual(
+		n.WithStart(1).WithEnd(2), n.WithStart(3).WithEnd(4)))
+	var zero FiniteNumber
+	assert.True(t, SequenceEqual(&zero, n.WithEnd(0)))
+	assert.False(t, SequenceEqual(&zero, n.WithEnd(1)))
+}
+
+func TestSequenceEqualWithGaps(t *testing.T) {
+	ones := MustNumber(nil, []int{1}, 0)
+	assert.False(t, SequenceEqual(ones.Stride(2).WithEnd(10), ones.WithEnd(5)))
+	assert.False(t, SequenceEqual(ones.WithEnd(5), ones.Stride(2).WithEnd(10)))
+	assert.True(t, SequenceEqual(ones.Stride(1).WithEnd(10), ones.WithEnd(10)))
+	evens, _ := AsFiniteSequence(FilterSeq(
+		ones.WithEnd(10), func(index, digit int) bool { return index%2 == 0 }))
+	assert.True(t, SequenceEqual(evens, ones.Stride(2).WithEnd(10)))
+	assert.False(t, SequenceEqual(evens, ones.Stride(2).WithEnd(12)))
+}
+
+func TestSequenceEqualLarge(t *testing.T) {
+	n := Sqrt(2)
+	assert.True(t, SequenceEqual(n.WithEnd(10000), Sqrt(2).WithEnd(10000)))
+	changed, _ := AsFiniteSequence(MapSeq(
+		n.WithEnd(10000),
+		func(index, digit int) int {
+			if index == 9000 {
+				return (digit + 1) % 10
+			}
+			return digit
+		}))
+	assert.False(t, SequenceEqual(n.WithEnd(10000), changed))
+	assert.Equal(t, -1, CompareSequences(n.WithEnd(9000), changed))
+	assert.Equal(t, 0, CompareSequences(n.WithEnd(9000), changed.WithEnd(9000)))
+	assert.Equal(
+		t, 1, CompareSequences(n.WithStart(1).WithEnd(10000), n.WithEnd(9999)))
+}
+
+func TestCompareSequences(t *testing.T) {
+	// 1.414213562
+	n := Sqrt(2)
+	assert.Equal(t, 0, CompareSequences(n.WithEnd(4), n.WithEnd(4)))
+
+	// "14" and "14" at different positions
+	assert.Equal(t, 0, CompareSequences(
+		n.WithEnd(2), n.WithStart(2).WithEnd(4)))
+
+	// "1414" vs "1421"
+	assert.Equal(t, -1, CompareSequences(
+		n.WithEnd(4), n.WithStart(2).WithEnd(6)))
+	assert.Equal(t, 1, CompareSequences(
+		n.WithStart(2).WithEnd(6), n.WithEnd(4)))
+
+	// "141" is a prefix of "1414"
+	assert.Equal(t, -1, CompareSequences(n.WithEnd(3), n.WithEnd(4)))
+	assert.Equal(t, 1, CompareSequences(n.WithEnd(4), n.WithEnd(3)))
+	var zero FiniteNumber
+	assert.Equal(t, 0, CompareSequences(&zero, n.WithEnd(0)))
+	assert.Equal(t, -1, CompareSequences(&zero, n.WithEnd(1)))
+}
+
+func TestFrequencies(t *testing.T) {
+	// 1.414213562
+	assert.Equal(
+		t,
+		[10]int{0, 3, 2, 1, 2, 1, 1, 0, 0, 0},
+		Frequencies(Sqrt(2).WithEnd(10)))
+	assert.Equal(
+		t,
+		[10]int{0, 2, 2, 1, 2, 1, 1, 0, 0, 0},
+		Frequencies(Sqrt(2).WithStart(1).WithEnd(10)))
+	freq := Frequencies(Sqrt(2).WithEnd(10000))
+	total := 0
+	for _, count := range freq {
+		total += count
+	}
+	assert.Equal(t, 10000, total)
+	var zero FiniteNumber
+	assert.Equal(t, [10]int{}, Frequencies(&zero))
+}
+
+func TestStartAndEnd(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, 0, n.Start())
+	assert.Equal(t, 3, n.WithStart(3).Start())
+	fs := n.WithStart(3).WithEnd(7)
+	assert.Equal(t, 3, fs.Start())
+	end, ok := fs.End()
+	assert.True(t, ok)
+	assert.Equal(t, 7, end)
+	end, ok = n.WithSignificant(5).End()
+	assert.True(t, ok)
+	assert.Equal(t, 5, end)
+	_, ok = Sqrt(256).(*FiniteNumber).End()
+	assert.False(t, ok)
+	assert.Equal(t, 1, Sqrt(256).WithStart(1).Start())
+	strided := n.WithStart(3).WithEnd(20).Stride(4).(FiniteSequence)
+	assert.Equal(t, 3, strided.Start())
+	end, ok = strided.End()
+	assert.True(t, ok)
+	assert.Equal(t, 20, end)
+	mapped := MapSeq(
+		n.WithStart(2).WithEnd(10),
+		func(index, digit int) int { return digit }).(FiniteSequence)
+	assert.Equal(t, 2, mapped.Start())
+	end, ok = mapped.End()
+	assert.True(t, ok)
+	assert.Equal(t, 10, end)
+	end, ok = mapped.WithEnd(6).End()
+	assert.True(t, ok)
+	assert.Equal(t, 6, end)
+	end, ok = MapSeq(n, func(index, digit int) int { return digit }).WithEnd(8).End()
+	assert.True(t, ok)
+	assert.Equal(t, 8, end)
+	var zero FiniteNumber
+	assert.Equal(t, 0, zero.Start())
+	end, ok = zero.End()
+	assert.True(t, ok)
+	assert.Equal(t, 0, end)
+}
+
+func TestTakeAndDrop(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "1414", AsString(Take(n, 4)))
+	assert.Equal(t, "4213", AsString(Take(n.WithStart(3), 4)))
+	assert.Empty(t, AsString(Take(n.WithStart(3), 0)))
+	assert.Empty(t, AsString(Take(n.WithStart(3), -2)))
+	fs, ok := AsFiniteSequence(Drop(n.WithEnd(7), 3))
+	assert.True(t, ok)
+	assert.Equal(t, "4213", AsString(fs))
+	fs, ok = AsFiniteSequence(Drop(n.WithStart(2).WithEnd(7), 2))
+	assert.True(t, ok)
+	assert.Equal(t, "213", AsString(fs))
+	assert.Equal(t, 3, Drop(n, 3).Start())
+	s := n.WithStart(1)
+	assert.Same(t, s, Drop(s, 0))
+	assert.Same(t, s, Drop(s, -1))
+	fs, ok = AsFiniteSequence(Drop(n.WithEnd(7), math.MaxInt))
+	assert.True(t, ok)
+	assert.Empty(t, AsString(fs))
+	assert.Equal(t, "4213562", AsString(Take(n.WithStart(3).WithEnd(10), math.MaxInt)))
+}
+
+func TestTakeAndDropWithGaps(t *testing.T) {
+	// 1.41421356237 with positions 0, 3, 6, 9, and 12 being 1, 4, 3, 2, 3
+	strided := Sqrt(2).Stride(3)
+	assert.Equal(t, "14323", AsString(Take(strided, 5)))
+	dropped := Drop(strided, 2)
+	assert.Equal(t, 6, dropped.Start())
+	assert.Equal(t, "323", AsString(Take(dropped, 3)))
+
+	// Even digits of 1.41421356237 are 4, 4, 2, 6, 2
+	filtered, _ := AsFiniteSequence(FilterSeq(
+		Sqrt(2).WithEnd(12), even))
+	assert.Equal(t, "442", AsString(Take(filtered, 3)))
+	assert.Equal(t, "44262", AsString(Take(filtered, 10)))
+	fs, ok := AsFiniteSequence(Drop(filtered, 3))
+	assert.True(t, ok)
+	assert.Equal(t, "62", AsString(fs))
+	fs, ok = AsFiniteSequence(Drop(filtered, 10))
+	assert.True(t, ok)
+	assert.Empty(t, AsString(fs))
+}
+
+func TestLastN(t *testing.T) {
+	// 1.414213562
+	s := Sqrt(2).WithEnd(10)
+	assert.Equal(t, "3562", AsString(LastN(s, 4)))
+	assert.Equal(t, 6, LastN(s, 4).Start())
+	assert.Same(t, s, LastN(s, 10))
+	assert.Same(t, s, LastN(s, 11))
+	assert.Empty(t, AsString(LastN(s, 0)))
+	assert.Empty(t, AsString(LastN(s, -1)))
+	assert.Equal(t, "56", AsString(LastN(Sqrt(2).WithSignificant(9), 2)))
+	strided := Sqrt(2).WithEnd(10).Stride(3).(FiniteSequence)
+	assert.Equal(t, "32", AsString(LastN(strided, 2)))
+	assert.Equal(t, "16", AsString(LastN(Sqrt(256).(*FiniteNumber), 5)))
+}
+
+func TestCount(t *testing.T) {
+	// 1.414213562
+	s := Sqrt(2).WithEnd(10)
+	assert.Equal(t, 3, Count(s, 1))
+	assert.Equal(t, 2, Count(s, 4))
+	assert.Equal(t, 0, Count(s, 7))
+	assert.Equal(t, 0, Count(s, 10))
+	assert.Equal(t, 2, Count(Sqrt(2).WithStart(2).WithEnd(10), 1))
+	assert.Equal(t, 5, CountFunc(s, func(digit int) bool {
+		return digit%2 == 0
+	}))
+	var zero FiniteNumber
+	assert.Equal(t, 0, Count(&zero, 0))
+}
+
+func TestCountLarge(t *testing.T) {
+	s := Sqrt(2).WithStart(5).WithEnd(10005)
+	frequencies := Frequencies(s)
+	total := 0
+	for digit, frequency := range frequencies {
+		assert.Equal(t, frequency, Count(s, digit))
+		total += frequency
+	}
+	assert.Equal(t, 10000, total)
+	assert.Equal(
+		t,
+		frequencies[1]+frequencies[3],
+		CountFunc(s, func(digit int) bool { return digit == 1 || digit == 3 }))
+}
+
+func TestAllInRangeBackward(t *testing.T) {
+	n := Sqrt(2).WithStart(3).WithEnd(7)
+	iterator := n.AllInRangeBackward(2, 8)
+	assert.Equal(t, []int{3, 1, 2, 4}, collect(iterator, 0))
+	assert.Equal(t, []int{3, 1, 2, 4}, collect(iterator, 0))
+	iterator = n.AllInRangeBackward(4, 6)
+	assert.Equal(t, []int{1, 2}, collect(iterator, 0))
+	iterator = n.AllInRangeBackward(-1, 3)
+	assert.Empty(t, collect(iterator, 0))
+	nn := Sqrt(2).WithSignificant(10)
+	iterator = nn.AllInRangeBackward(2, 6)
+	assert.Equal(t, []int{1, 2, 4, 1}, collect(iterator, 0))
+	iterator = nn.AllInRangeBackward(2, -4)
+	assert.Empty(t, collect(iterator, 0))
+	strided := Sqrt(2).WithEnd(9).Stride(2).(FiniteSequence)
+	iterator = strided.AllInRangeBackward(1, 7)
+	assert.Equal(t, []int{3, 2, 1}, collect(iterator, 0))
+	mapped := MapSeq(
+		Sqrt(2).WithEnd(9),
+		func(index, digit int) int { return 9 - digit }).(FiniteSequence)
+	iterator = mapped.AllInRangeBackward(1, 4)
+	assert.Equal(t, []int{5, 8, 5}, collect(iterator, 0))
+	var zero FiniteNumber
+	iterator = zero.AllInRangeBackward(3, 7)
+	assert.Empty(t, collect(iterator, 0))
+}
+
 func TestReverse(t *testing.T) {
 	// n = 2.2360679
 	n := Sqrt(5).WithSignificant(8)
@@ -190,6 +503,154 @@ func TestSquareRootString(t *testing.T) {
 	assert.Equal(t, "3.162277660168379", number.String())
 }
 
+func TestInvSqrt(t *testing.T) {
+	assert.Equal(
+		t,
+		"0.707106781186547524400844362104849039",
+		fmt.Sprintf("%.36f", InvSqrt(2)))
+	assert.Equal(
+		t,
+		"0.577350269189625764509148780501957455",
+		fmt.Sprintf("%.36f", InvSqrt(3)))
+	n := InvSqrt(1000003)
+	assert.Equal(t, -3, n.Exponent())
+	assert.Equal(t, "0.0009999985000033749915", fmt.Sprintf("%.22f", n))
+	assert.Equal(t, "0.25", InvSqrt(16).String())
+	assert.Equal(t, "1", InvSqrt(1).String())
+	assert.Panics(t, func() { InvSqrt(0) })
+	assert.Panics(t, func() { InvSqrt(-4) })
+}
+
+func TestSqrtScaled(t *testing.T) {
+	assert.Equal(
+		t,
+		fmt.Sprint(Sqrt(3).WithSignificant(100).withExponent(500001)),
+		fmt.Sprint(SqrtScaled(big.NewInt(3), 1000000).WithSignificant(100)))
+	assert.Equal(
+		t,
+		fmt.Sprint(Sqrt(30).WithSignificant(100).withExponent(-499999)),
+		fmt.Sprint(SqrtScaled(big.NewInt(3), -999999).WithSignificant(100)))
+	assert.Equal(t, "0.3e+500001", SqrtScaled(big.NewInt(9), 1000000).String())
+	assert.Equal(t, "300", SqrtScaled(big.NewInt(9), 4).String())
+	assert.Equal(t, "0.03", SqrtScaled(big.NewInt(9), -4).String())
+	assert.Equal(t, "0.3", SqrtScaled(big.NewInt(90), -3).String())
+	assert.True(t, SqrtScaled(big.NewInt(0), 1000000).IsZero())
+	assert.Panics(t, func() { SqrtScaled(big.NewInt(-1), 2) })
+}
+
+func TestHypot(t *testing.T) {
+	n := Hypot(3, -4)
+	assert.Equal(t, "5", n.String())
+	n = Hypot(math.MaxInt64, math.MaxInt64)
+	assert.Equal(t, 20, n.Exponent())
+	assert.Equal(t, "13043817825332782210.9", fmt.Sprintf("%.1f", n))
+	assert.Equal(t, zeroNumber, Hypot(0, 0))
+}
+
+func TestHypotBigRat(t *testing.T) {
+	a := big.NewRat(3, 7)
+	b := big.NewRat(-4, 7)
+	n := HypotBigRat(a, b)
+	assert.Equal(t, "0.7142857142857142", n.String())
+	assert.Equal(t, big.NewRat(3, 7), a)
+	assert.Equal(t, big.NewRat(-4, 7), b)
+}
+
+func TestGeometricMean(t *testing.T) {
+	n := GeometricMean([]*big.Rat{big.NewRat(2, 1), big.NewRat(8, 1)})
+	assert.Equal(t, "4", n.String())
+	n = GeometricMean(
+		[]*big.Rat{big.NewRat(1, 1), big.NewRat(2, 1), big.NewRat(3, 1)})
+	assert.Equal(t, "1.817120592832139", n.String())
+	n = GeometricMean([]*big.Rat{
+		big.NewRat(1, 2),
+		big.NewRat(2, 1),
+		big.NewRat(3, 1),
+		big.NewRat(4, 1),
+		big.NewRat(5, 1)})
+	assert.Equal(t, "2.267933155266054", n.String())
+	n = GeometricMean([]*big.Rat{big.NewRat(3, 7)})
+	assert.Equal(t, "0.4285714285714285", n.String())
+	n = GeometricMean([]*big.Rat{big.NewRat(3, 7), big.NewRat(0, 1)})
+	assert.Equal(t, zeroNumber, n)
+}
+
+func TestGeometricMeanPanics(t *testing.T) {
+	assert.Panics(t, func() { GeometricMean(nil) })
+	assert.Panics(t, func() {
+		GeometricMean([]*big.Rat{big.NewRat(-1, 1), big.NewRat(-4, 1)})
+	})
+}
+
+func TestNRootManager(t *testing.T) {
+	n := nRootFrac(big.NewInt(2), one, 7)
+	assert.Equal(t, "1.104089513673812", n.String())
+	g := newNRootGenerator(big.NewInt(78125), one, rootManagerFactory(7))
+	n = newNumber(g.Generate())
+	assert.Equal(t, "5", n.String())
+}
+
+func TestPerfectPowers(t *testing.T) {
+	assertFinite(t, "317", Sqrt(100489))
+	assertFinite(t, "2", SqrtRat(8, 2))
+	assertFinite(t, "0.05", SqrtRat(1, 400))
+	assertFinite(t, "1", SqrtBigRat(big.NewRat(7, 7)))
+	assertFinite(t, "3278", CubeRoot(35223040952))
+	assertFinite(t, "0.5", CubeRootRat(1, 8))
+	assertFinite(t, "1.25", CubeRootRat(125, 64))
+	assertFinite(
+		t,
+		"0.1e+31",
+		SqrtBigInt(new(big.Int).Exp(ten, big.NewInt(60), nil)))
+	assertFinite(
+		t,
+		"4",
+		GeometricMean([]*big.Rat{big.NewRat(2, 1), big.NewRat(8, 1)}))
+}
+
+func TestNotPerfectPowers(t *testing.T) {
+	n := SqrtRat(1, 9)
+	assert.False(t, n.IsTerminating())
+	assert.Equal(t, "0.3333333333333333", n.String())
+	assert.False(t, Sqrt(6).IsTerminating())
+	assert.False(t, CubeRoot(9).IsTerminating())
+}
+
+func TestIsTerminating(t *testing.T) {
+	n := NewNumber(&testgenerator{first: 1, second: 2, exp: 1})
+	assert.False(t, n.IsTerminating())
+	n = NewNumber(newRepeatingGenerator([]int{1, 2, 3}, nil, 0))
+	assert.False(t, n.IsTerminating())
+	assert.Equal(t, -1, n.At(5))
+	assert.True(t, n.IsTerminating())
+	assert.True(t, zeroNumber.IsTerminating())
+	assert.True(t, Sqrt(6).WithSignificant(5).IsTerminating())
+}
+
+func TestIntRoot(t *testing.T) {
+	root, ok := intRoot(big.NewInt(1), 5)
+	assert.Equal(t, big.NewInt(1), root)
+	assert.True(t, ok)
+	root, ok = intRoot(big.NewInt(242), 5)
+	assert.Equal(t, big.NewInt(2), root)
+	assert.False(t, ok)
+	root, ok = intRoot(big.NewInt(243), 5)
+	assert.Equal(t, big.NewInt(3), root)
+	assert.True(t, ok)
+	root, ok = intRoot(big.NewInt(17), 1)
+	assert.Equal(t, big.NewInt(17), root)
+	assert.True(t, ok)
+}
+
+func assertFinite(t *testing.T, expected string, n Number) {
+	t.Helper()
+	fn, ok := n.(*FiniteNumber)
+	if assert.True(t, ok) {
+		assert.True(t, fn.IsTerminating())
+		assert.Equal(t, expected, fn.String())
+	}
+}
+
 func TestCubeRoot2(t *testing.T) {
 	assert.Equal(t, "1.25992104989487", fmt.Sprintf("%.15g", CubeRoot(2)))
 }
@@ -217,12 +678,146 @@ func TestCubeRootBigRat(t *testing.T) {
 	assert.Equal(t, "163.9", n.String())
 }
 
+func TestCubeRootBigFloat(t *testing.T) {
+	n := CubeRootBigFloat(big.NewFloat(2))
+	assert.Equal(t, "1.25992104989487", fmt.Sprintf("%.15g", n))
+	n = CubeRootBigFloat(big.NewFloat(3.375))
+	assert.Equal(t, "1.5", n.String())
+	assert.True(t, CubeRootBigFloat(new(big.Float)).IsZero())
+}
+
+func TestCubeRootBigFloatLargeExponent(t *testing.T) {
+	x := new(big.Float).SetMantExp(big.NewFloat(0.5), 300002)
+	n := CubeRootBigFloat(x)
+	assert.Equal(t, 30104, n.Exponent())
+	assert.Equal(
+		t,
+		"1.2586637658778590659230441583",
+		fmt.Sprintf("%.28f", n.WithSignificant(30).withExponent(1)))
+	x = new(big.Float).SetMantExp(big.NewFloat(0.5), -300000)
+	n = CubeRootBigFloat(x)
+	assert.Equal(t, -30103, n.Exponent())
+	assert.Equal(
+		t,
+		"7.9449335645453081285831837235",
+		fmt.Sprintf("%.28f", n.WithSignificant(29).withExponent(1)))
+}
+
+func TestSqrtBigFloat(t *testing.T) {
+	n := SqrtBigFloat(big.NewFloat(2))
+	assert.Equal(t, "1.41421356237309", fmt.Sprintf("%.15g", n))
+	assert.Equal(t, "1.5", SqrtBigFloat(big.NewFloat(2.25)).String())
+	assert.True(t, SqrtBigFloat(new(big.Float)).IsZero())
+	assert.Panics(t, func() { SqrtBigFloat(big.NewFloat(-1)) })
+	assert.Panics(t, func() { SqrtBigFloat(new(big.Float).SetInf(false)) })
+}
+
+func TestBigFloatRootLargeExponentMatchesRat(t *testing.T) {
+	mant := big.NewFloat(0.7071067811865476)
+	for _, exp := range []int{
+		kBigFloatMaxRatExp + 1,
+		kBigFloatMaxRatExp + 2,
+		-kBigFloatMaxRatExp - 1,
+		-kBigFloatMaxRatExp - 2} {
+		x := new(big.Float).SetMantExp(mant, exp)
+		r, _ := x.Rat(nil)
+		for _, n := range []int{2, 3} {
+			expected := nRootFrac(r.Num(), r.Denom(), n)
+			actual := nRootBigFloat(x, n)
+			assert.Equal(t, expected.Exponent(), actual.Exponent())
+			assert.True(
+				t,
+				SequenceEqual(expected.WithEnd(300), actual.WithEnd(300)),
+				"exp=%d n=%d",
+				exp,
+				n)
+		}
+	}
+}
+
+func TestBigFloatRootNearMaxExpUsesLittleMemory(t *testing.T) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	x := new(big.Float).SetMantExp(big.NewFloat(0.75), big.MaxExp)
+	n := SqrtBigFloat(x)
+	assert.Equal(t, 323228497, n.Exponent())
+	assert.NotEqual(t, -1, n.At(99))
+	c := CubeRootBigFloat(new(big.Float).SetMantExp(big.NewFloat(0.75), big.MinExp))
+	assert.Equal(t, -215485664, c.Exponent())
+	assert.NotEqual(t, -1, c.At(99))
+	runtime.ReadMemStats(&after)
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(50<<20))
+}
+
+func TestCubeRootBigFloatPanics(t *testing.T) {
+	assert.Panics(t, func() { CubeRootBigFloat(big.NewFloat(-1)) })
+	assert.Panics(t, func() { CubeRootBigFloat(new(big.Float).SetInf(false)) })
+}
+
+func TestNRootBigRat(t *testing.T) {
+	assert.Equal(
+		t,
+		"1.10408951367381233764950538762334472",
+		fmt.Sprintf("%.35f", NRootBigRat(big.NewRat(2, 1), 7)))
+	assert.Equal(
+		t,
+		"0.84412087984411007332007790208314917",
+		fmt.Sprintf("%.35f", NRootBigRat(big.NewRat(3, 7), 5)))
+	assert.Equal(
+		t,
+		Sqrt(2).WithSignificant(50).String(),
+		NRootBigRat(big.NewRat(2, 1), 2).WithSignificant(50).String())
+	assert.Equal(
+		t,
+		CubeRoot(2).WithSignificant(50).String(),
+		NRootBigRat(big.NewRat(2, 1), 3).WithSignificant(50).String())
+	assert.Equal(t, "0.75", NRootBigRat(big.NewRat(3, 4), 1).String())
+	assert.Equal(t, "1.5", NRootBigRat(big.NewRat(243, 32), 5).String())
+	assert.True(t, NRootBigRat(new(big.Rat), 4).IsZero())
+}
+
+func TestNRootBigRatPanics(t *testing.T) {
+	assert.Panics(t, func() { NRootBigRat(big.NewRat(2, 1), 0) })
+	assert.Panics(t, func() { NRootBigRat(big.NewRat(-2, 1), 3) })
+}
+
 func TestCubeRootSmallRat(t *testing.T) {
 	n := CubeRootRat(2, 73952)
 	assert.Equal(t, -1, n.Exponent())
 	assert.Equal(t, "0.030016498129266", fmt.Sprintf("%.14g", n))
 }
 
+func TestShortest(t *testing.T) {
+	assertShortest(t, "0", nil, 0)
+	assertShortest(t, "1.25", []int{1, 2, 5}, 1)
+	assertShortest(t, "1.25", []int{1, 2, 5, 0, 0}, 1)
+	assertShortest(t, "12500", []int{1, 2, 5}, 5)
+	assertShortest(t, "1250000", []int{1, 2, 5}, 7)
+	assertShortest(t, "1.25e+08", []int{1, 2, 5}, 9)
+	assertShortest(t, "1e+05", []int{1}, 6)
+	assertShortest(t, "10000", []int{1}, 5)
+	assertShortest(t, "0.0015", []int{1, 5}, -2)
+	assertShortest(t, "0.00015", []int{1, 5}, -3)
+	assertShortest(t, "1.5e-05", []int{1, 5}, -4)
+	assertShortest(t, "0.001", []int{1}, -2)
+	assertShortest(t, "1e-04", []int{1}, -3)
+	assertShortest(t, "1.2e+100", []int{1, 2}, 101)
+	assertShortest(t, "1.2e-100", []int{1, 2}, -99)
+	assert.Equal(t, "1.414213562", Sqrt(2).WithSignificant(10).Shortest())
+}
+
+func assertShortest(
+	t *testing.T, expected string, digits []int, exp int) {
+	t.Helper()
+	n, err := NewFiniteNumber(digits, exp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, n.Shortest())
+	r, ok := new(big.Rat).SetString(expected)
+	assert.True(t, ok)
+	actual, _ := n.AsRat()
+	assert.Zero(t, r.Cmp(actual))
+}
+
 func TestExact(t *testing.T) {
 	n := fakeNumber().WithSignificant(10).withExponent(0)
 	assert.Equal(t, "0.1234567890", n.(*FiniteNumber).Exact())
@@ -388,6 +983,157 @@ func TestWithSignificant(t *testing.T) {
 	assert.Equal(t, "1.41421", n.Exact())
 }
 
+func TestEnclosure(t *testing.T) {
+	lo, hi := Sqrt(2).Enclosure(5)
+	assert.Equal(t, "1.4142", lo.Exact())
+	assert.Equal(t, "1.4143", hi.Exact())
+	lo, hi = Sqrt(2).Enclosure(0)
+	assert.Equal(t, zeroNumber, lo)
+	assert.Equal(t, "10", hi.Exact())
+	lo, hi = Sqrt(100489).Enclosure(3)
+	assert.Same(t, lo, hi)
+	assert.Equal(t, "317", hi.Exact())
+	lo, hi = zeroNumber.Enclosure(3)
+	assert.Equal(t, zeroNumber, lo)
+	assert.Equal(t, zeroNumber, hi)
+}
+
+func TestEnclosureCarry(t *testing.T) {
+	n, _ := NewNumberForTesting([]int{9, 9}, []int{9, 8}, -1)
+	lo, hi := n.Enclosure(3)
+	assert.Equal(t, "0.0999", lo.Exact())
+	assert.Equal(t, "0.1", hi.Exact())
+	assert.Equal(t, -1, lo.Exponent())
+	assert.Equal(t, 0, hi.Exponent())
+	lo, hi = n.Enclosure(4)
+	assert.Equal(t, "0.09998", lo.Exact())
+	assert.Equal(t, "0.09999", hi.Exact())
+	lo, hi = n.Enclosure(5)
+	assert.Equal(t, "0.099989", lo.Exact())
+	assert.Equal(t, "0.09999", hi.Exact())
+}
+
+func TestEnclosurePanics(t *testing.T) {
+	assert.Panics(t, func() { Sqrt(2).Enclosure(-1) })
+}
+
+func TestRound(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, "1.4142", n.Round(5).Exact())
+	assert.Equal(t, "1.41421", n.Round(6).Exact())
+	assert.Equal(t, "1.414214", n.Round(7).Exact())
+	assert.Equal(t, "1", n.Round(1).Exact())
+	assert.Equal(t, zeroNumber, n.Round(0))
+	assert.Equal(t, "10", Sqrt(99).Round(1).Exact())
+	assert.Equal(t, "10", Sqrt(99).Round(0).Exact())
+	assert.Equal(t, zeroNumber, zeroNumber.Round(3))
+}
+
+func TestRoundHalfEven(t *testing.T) {
+	n, _ := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1.2", n.Round(2).Exact())
+	n, _ = NewFiniteNumber([]int{1, 3, 5}, 1)
+	assert.Equal(t, "1.4", n.Round(2).Exact())
+	n, _ = NewFiniteNumber([]int{1, 2, 5, 0, 0, 1}, 1)
+	assert.Equal(t, "1.3", n.Round(2).Exact())
+	n, _ = NewFiniteNumber([]int{5}, 0)
+	assert.Equal(t, zeroNumber, n.Round(0))
+	n, _ = NewFiniteNumber([]int{9, 5}, 0)
+	assert.Equal(t, "1", n.Round(1).Exact())
+	nn, _ := NewNumberForTesting([]int{1, 2, 5}, []int{0}, 1)
+	assert.Equal(t, "1.2", nn.Round(2).Exact())
+	nn, _ = NewNumberForTesting([]int{1, 2, 5}, []int{0, 0, 0, 1}, 1)
+	assert.Equal(t, "1.3", nn.Round(2).Exact())
+	nn, _ = NewNumberForTesting([]int{1, 2}, []int{5, 0}, 1)
+	assert.Equal(t, "1.3", nn.Round(2).Exact())
+}
+
+func TestRoundToPlaces(t *testing.T) {
+	n := Sqrt(20000)
+	assert.Equal(t, "141.42136", n.RoundToPlaces(5).Exact())
+	assert.Equal(t, "141", n.RoundToPlaces(0).Exact())
+	assert.Equal(t, "140", n.RoundToPlaces(-1).Exact())
+	assert.Equal(t, "100", n.RoundToPlaces(-2).Exact())
+	assert.Equal(t, zeroNumber, n.RoundToPlaces(-3))
+	assert.Equal(t, zeroNumber, n.RoundToPlaces(-4))
+	n = SqrtRat(2, 1000000)
+	assert.Equal(t, "0.00141", n.RoundToPlaces(5).Exact())
+	assert.Equal(t, "0.001", n.RoundToPlaces(3).Exact())
+	assert.Equal(t, zeroNumber, n.RoundToPlaces(2))
+	assert.Equal(t, zeroNumber, n.RoundToPlaces(1))
+	assert.Equal(t, zeroNumber, zeroNumber.RoundToPlaces(3))
+}
+
+func TestRoundToPlacesHalfEven(t *testing.T) {
+	n, _ := NewFiniteNumber([]int{5}, -2)
+	assert.Equal(t, zeroNumber, n.RoundToPlaces(2))
+	n, _ = NewFiniteNumber([]int{1, 5}, -1)
+	assert.Equal(t, "0.02", n.RoundToPlaces(2).Exact())
+}
+
+func TestFloorCeil(t *testing.T) {
+	assertFloorCeil(t, 141, 142, Sqrt(20000))
+	assertFloorCeil(t, 317, 317, Sqrt(100489))
+	assertFloorCeil(t, 1000, 1000, Sqrt(1000000))
+	assertFloorCeil(t, 0, 1, SqrtRat(1, 2))
+	assertFloorCeil(t, 0, 1, SqrtRat(1, 2000000))
+	assertFloorCeil(t, 0, 0, zeroNumber)
+	n, _ := NewNumberForTesting([]int{1, 2}, []int{0}, 2)
+	assertFloorCeil(t, 12, 12, n)
+	n, _ = NewNumberForTesting([]int{1, 2}, []int{0, 0, 0, 7}, 2)
+	assertFloorCeil(t, 12, 13, n)
+	n, _ = NewNumberForTesting([]int{1, 2}, []int{3}, 5)
+	assertFloorCeil(t, 12333, 12334, n)
+}
+
+func TestCeilUndetermined(t *testing.T) {
+	n := NewNumber(oneThenZerosGenerator{})
+	assert.Equal(t, big.NewInt(1), n.Floor())
+	assert.Zero(t, n.At(1))
+	_, err := n.Ceil()
+	assert.Equal(t, ErrUndetermined, err)
+}
+
+func assertFloorCeil(t *testing.T, floor, ceil int64, n Number) {
+	t.Helper()
+	assert.Equal(t, big.NewInt(floor), n.Floor())
+	actual, err := n.Ceil()
+	if assert.NoError(t, err) {
+		assert.Equal(t, big.NewInt(ceil), actual)
+	}
+}
+
+func TestIntegerFractionalPart(t *testing.T) {
+	n := Sqrt(20000)
+	assert.Equal(t, "141", AsString(n.IntegerPart()))
+	assert.Equal(t, []int{4, 2, 1}, take(n.FractionalPart().Values(), 3))
+	for index := range n.FractionalPart().All() {
+		assert.Equal(t, 3, index)
+		break
+	}
+	n = SqrtRat(2, 1000000)
+	assert.Empty(t, AsString(n.IntegerPart()))
+	assert.Equal(t, []int{1, 4, 1}, take(n.FractionalPart().Values(), 3))
+	for index := range n.FractionalPart().All() {
+		assert.Equal(t, 0, index)
+		break
+	}
+	n = Sqrt(100489000000)
+	assert.Equal(t, "317", AsString(n.IntegerPart()))
+	assert.Empty(t, slices.Collect(n.FractionalPart().Values()))
+	fn, _ := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.Equal(t, "1", AsString(fn.IntegerPart()))
+	fs, ok := fn.FractionalPart().(FiniteSequence)
+	assert.True(t, ok)
+	assert.Equal(t, "25", AsString(fs))
+	assert.Empty(t, AsString(zeroNumber.IntegerPart()))
+	assert.Empty(t, slices.Collect(zeroNumber.FractionalPart().Values()))
+}
+
+func TestRoundPanics(t *testing.T) {
+	assert.Panics(t, func() { Sqrt(2).Round(-1) })
+}
+
 func TestWithSignificantPanics(t *testing.T) {
 	n := Sqrt(2)
 	assert.Panics(t, func() { n.WithSignificant(-1) })
@@ -396,7 +1142,7 @@ func TestWithSignificantPanics(t *testing.T) {
 
 func TestWithSignificantToZero(t *testing.T) {
 	assert.Zero(t, *zeroNumber)
-	assert.Same(t, zeroNumber, Sqrt(2).WithSignificant(0))
+	assert.Equal(t, zeroNumber, Sqrt(2).WithSignificant(0))
 }
 
 func TestZeroNumber(t *testing.T) {
@@ -512,6 +1258,28 @@ func TestNumberAtSig(t *testing.T) {
 	assert.Equal(t, -1, n.At(2000000000))
 }
 
+func TestBatchAt(t *testing.T) {
+	n := fakeNumber().WithSignificant(357)
+	assert.Equal(
+		t,
+		[]int{-1, 3, 1, 4, 7, 3, -1, -1},
+		BatchAt(n, []int{-1, 322, 0, 303, 356, 322, 357, 2000000000}))
+	assert.Equal(t, []int{7, 4}, BatchAt(Sqrt(2), []int{999, 1}))
+	assert.Equal(t, []int{-1, -1}, BatchAt(zeroNumber, []int{0, 5}))
+	assert.Empty(t, BatchAt(Sqrt(2), nil))
+}
+
+func TestAtMany(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(t, []int{2, 1, 4, -1}, n.AtMany([]int{9, 0, 3, -1}))
+	fn := Sqrt(256).(*FiniteNumber)
+	assert.Equal(t, []int{6, 1, -1}, fn.AtMany([]int{1, 0, 2}))
+	assert.Equal(
+		t,
+		BatchAt(fakeNumber(), []int{62, 404, 7}),
+		fakeNumber().AtMany([]int{62, 404, 7}))
+}
+
 func TestNumberSubSequence(t *testing.T) {
 	n := fakeNumber()
 	assertStartsAt(t, n, 0)
@@ -544,6 +1312,16 @@ func TestNumberSubSequenceWithStartAndEnd(t *testing.T) {
 	assertEmpty(t, n.FiniteWithStart(542))
 }
 
+func TestSequenceString(t *testing.T) {
+	n := fakeNumber()
+	assert.Equal(t, "3456789012345678...", fmt.Sprint(n.WithStart(2)))
+	assert.Equal(t, "3456789012345678", fmt.Sprint(n.WithStart(2).WithEnd(18)))
+	assert.Equal(t, "3456789012345678...", fmt.Sprint(n.WithStart(2).WithEnd(19)))
+	assert.Equal(t, "34", fmt.Sprintf("%v", n.WithEnd(4).FiniteWithStart(2)))
+	assert.Equal(t, "", fmt.Sprint(n.WithStart(5).WithEnd(5)))
+	assert.Equal(t, "0.1234567890123456", fmt.Sprint(n.WithEnd(100)))
+}
+
 func TestNumberSubSequenceSame(t *testing.T) {
 	n := fakeNumber()
 	assert.Same(t, n, n.WithStart(0))
@@ -724,6 +1502,14 @@ func (g *testgenerator) Generate() (func() int, int) {
 	return digits, g.exp
 }
 
+// oneThenZerosGenerator generates 1.000... with infinitely many zeros.
+type oneThenZerosGenerator struct {
+}
+
+func (g oneThenZerosGenerator) Generate() (func() int, int) {
+	return firstAndThen(1, func() int { return 0 }), 1
+}
+
 type badgenerator struct {
 	notFirst bool
 }
@@ -739,3 +1525,278 @@ func (g *badgenerator) Generate() (func() int, int) {
 func take(s iter.Seq[int], n int) []int {
 	return slices.Collect(itertools.Take(n, s))
 }
+
+func TestBigFloat(t *testing.T) {
+	f, _ := Sqrt(2).BigFloat(53).Float64()
+	assert.Equal(t, math.Sqrt2, f)
+	f, _ = Sqrt(3).BigFloat(0).Float64()
+	assert.Equal(t, math.Sqrt(3), f)
+	assert.Equal(t, uint(64), Sqrt(3).BigFloat(0).Prec())
+	expected, _, _ := big.ParseFloat(
+		"1.41421356237309504880168872420969807856967187537694807317667973799",
+		10, 200, big.ToNearestEven)
+	assert.Equal(t, 0, Sqrt(2).BigFloat(200).Cmp(expected))
+	assert.Equal(t, "4", Sqrt(16).BigFloat(10).String())
+	assert.Equal(t, 0, zeroNumber.BigFloat(10).Sign())
+	third, err := NewNumberForTesting(nil, []int{3}, 0)
+	assert.NoError(t, err)
+	f, _ = third.BigFloat(53).Float64()
+	assert.Equal(t, 1.0/3.0, f)
+}
+
+func TestBigFloatRoundsToNearest(t *testing.T) {
+
+	// sqrt(7) = 10.1010010101..., so 4 bits round up to 10.11.
+	assert.Equal(t, 2.75, bigFloat64(Sqrt(7), 4))
+
+	// sqrt(17) = 100.0001111..., so 4 bits round down to 100.0.
+	assert.Equal(t, 4.0, bigFloat64(Sqrt(17), 4))
+}
+
+func bigFloat64(n Number, prec uint) float64 {
+	result, _ := n.BigFloat(prec).Float64()
+	return result
+}
+
+func TestRat(t *testing.T) {
+	assert.Equal(t, big.NewRat(141421, 100000), Sqrt(2).Rat(6))
+	assert.Equal(t, big.NewRat(14000, 1), Sqrt(200000000).Rat(2))
+	assert.Equal(t, big.NewRat(0, 1), Sqrt(2).Rat(0))
+	n, err := NewFiniteNumber([]int{2, 5}, -3)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 4000), n.Rat(100))
+	assert.Equal(t, big.NewRat(2, 10000), n.Rat(1))
+	assert.Panics(t, func() { Sqrt(2).Rat(-1) })
+}
+
+func TestExactRat(t *testing.T) {
+	n, err := NewFiniteNumber([]int{1, 2, 5}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(5, 4), n.ExactRat())
+	assert.Equal(t, big.NewRat(0, 1), zeroNumber.ExactRat())
+	assert.Equal(
+		t, big.NewRat(141421356, 100000000), Sqrt(2).WithSignificant(9).ExactRat())
+}
+
+func TestSetBigFloat(t *testing.T) {
+	dst := new(big.Float).SetPrec(53).SetMode(big.ToZero)
+	assert.Same(t, dst, SetBigFloat(dst, Sqrt(7)))
+	assert.Equal(t, uint(53), dst.Prec())
+	f, _ := dst.Float64()
+	assert.Equal(t, math.Nextafter(math.Sqrt(7), 0), f)
+
+	// sqrt(7) = 10.1010010101..., so 4 bits round down to 10.10.
+	dst.SetPrec(4)
+	f, _ = SetBigFloat(dst, Sqrt(7)).Float64()
+	assert.Equal(t, 2.5, f)
+	f, _ = SetBigFloat(dst.SetMode(big.AwayFromZero), Sqrt(16)).Float64()
+	assert.Equal(t, 4.0, f)
+	f, _ = SetBigFloat(new(big.Float), Sqrt(2)).Float64()
+	assert.Equal(t, math.Sqrt2, f)
+}
+
+func TestLen(t *testing.T) {
+	assert.Equal(t, 1000, Sqrt(2).WithSignificant(1000).Len())
+	assert.Equal(t, 1000, Sqrt(2).WithEnd(1000).Len())
+	assert.Equal(t, 995, Sqrt(2).WithStart(5).WithEnd(1000).Len())
+	assert.Equal(t, 0, Sqrt(2).WithStart(1000).WithEnd(1000).Len())
+	assert.Equal(t, 2, Sqrt(256).WithSignificant(1000).Len())
+	assert.Equal(t, 0, Sqrt(256).WithStart(5).WithEnd(1000).Len())
+	assert.Equal(t, 1, Sqrt(256).WithEnd(1000).FiniteWithStart(1).Len())
+	assert.Equal(t, 0, zeroNumber.Len())
+}
+
+func TestFiniteSequenceAt(t *testing.T) {
+	s := Sqrt(2).WithStart(3).WithEnd(10)
+	assert.Equal(t, -1, s.At(-1))
+	assert.Equal(t, -1, s.At(2))
+	assert.Equal(t, 4, s.At(3))
+	assert.Equal(t, 2, s.At(9))
+	assert.Equal(t, -1, s.At(10))
+	var fs FiniteSequence = Sqrt(256).WithSignificant(10)
+	assert.Equal(t, 6, fs.At(1))
+	assert.Equal(t, -1, fs.At(2))
+}
+
+func TestChunks(t *testing.T) {
+	s := Sqrt(2).WithStart(3).WithEnd(13)
+	var indexes []int
+	var chunks [][]int8
+	for index, chunk := range s.Chunks(4) {
+		indexes = append(indexes, index)
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, []int{3, 7, 11}, indexes)
+	assert.Equal(t, [][]int8{{4, 2, 1, 3}, {5, 6, 2, 3}, {7, 3}}, chunks)
+}
+
+func TestChunksLarge(t *testing.T) {
+	n := Sqrt(2)
+	var sb strings.Builder
+	for index, chunk := range n.Chunks(1000) {
+		if index >= 10000 {
+			break
+		}
+		for _, digit := range chunk {
+			sb.WriteByte('0' + byte(digit))
+		}
+	}
+	assert.Equal(t, AsString(n.WithEnd(10000)), sb.String())
+}
+
+func TestChunksFinite(t *testing.T) {
+	var chunks [][]int8
+	for _, chunk := range Sqrt(256).Chunks(4) {
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, [][]int8{{1, 6}}, chunks)
+	for range zeroNumber.Chunks(4) {
+		assert.Fail(t, "zero has no chunks")
+	}
+	assert.Panics(t, func() { Sqrt(2).Chunks(0) })
+}
+
+func TestValuesBase(t *testing.T) {
+	n := Sqrt(2)
+	assert.Equal(
+		t,
+		[]int{14, 14, 21, 35},
+		take(n.ValuesBase(2), 4))
+	assert.Equal(
+		t, []int{14, 14, 20}, slices.Collect(n.WithEnd(5).ValuesBase(2)))
+	assert.Equal(
+		t,
+		[]int{141421356237309504, 880168872420969807},
+		take(n.ValuesBase(18), 2))
+	assert.Equal(
+		t,
+		[]int{4213, 5600},
+		slices.Collect(n.WithStart(3).WithEnd(9).ValuesBase(4)))
+	assert.Equal(t, []int{1600}, slices.Collect(Sqrt(256).ValuesBase(4)))
+	assert.Empty(t, slices.Collect(zeroNumber.ValuesBase(4)))
+	assert.Equal(t, []int{1, 4, 1}, slices.Collect(n.WithEnd(3).ValuesBase(1)))
+	assert.Panics(t, func() { n.ValuesBase(0) })
+	assert.Panics(t, func() { n.ValuesBase(19) })
+}
+
+func TestCopyDigits(t *testing.T) {
+	dst := make([]int8, 5)
+	assert.Equal(t, 5, Sqrt(2).CopyDigits(dst, 3))
+	assert.Equal(t, []int8{4, 2, 1, 3, 5}, dst)
+	assert.Equal(t, 2, Sqrt(2).WithSignificant(5).CopyDigits(dst, 3))
+	assert.Equal(t, []int8{4, 2}, dst[:2])
+	assert.Zero(t, Sqrt(256).CopyDigits(dst, 2))
+	assert.Zero(t, Sqrt(2).CopyDigits(nil, 0))
+	large := make([]int8, 10000)
+	assert.Equal(t, 10000, Sqrt(2).CopyDigits(large, 1))
+	expected := make([]int8, 0, 10000)
+	for digit := range Sqrt(2).WithStart(1).WithEnd(10001).Values() {
+		expected = append(expected, int8(digit))
+	}
+	assert.Equal(t, expected, large)
+	assert.Panics(t, func() { Sqrt(2).CopyDigits(dst, -1) })
+}
+
+func TestDigitsPrefix(t *testing.T) {
+	assert.Equal(t, []int8{1, 4, 1, 4, 2}, Sqrt(2).DigitsPrefix(5))
+	assert.Equal(t, []int8{1, 4, 1}, Sqrt(2).WithSignificant(3).DigitsPrefix(5))
+	assert.Equal(t, []int8{1, 6}, Sqrt(256).DigitsPrefix(5))
+	assert.Empty(t, Sqrt(2).DigitsPrefix(0))
+	assert.Empty(t, zeroNumber.DigitsPrefix(5))
+	prefix := Sqrt(2).DigitsPrefix(10000)
+	assert.Len(t, prefix, 10000)
+	assert.Equal(t, 10000, cap(prefix))
+	assert.Panics(t, func() { Sqrt(2).DigitsPrefix(-1) })
+}
+
+func TestPreview(t *testing.T) {
+	assert.Equal(t, "14142…", Preview(Sqrt(2), 5))
+	assert.Equal(t, "4213…", Preview(Sqrt(2).WithStart(3), 4))
+	assert.Equal(t, "4213", Preview(Sqrt(2).WithStart(3).WithEnd(7), 4))
+	assert.Equal(t, "4213", Preview(Sqrt(2).WithStart(3).WithEnd(7), 10))
+	assert.Equal(t, "…", Preview(Sqrt(2), 0))
+	assert.Equal(t, "…", Preview(Sqrt(2), -1))
+	var zero FiniteNumber
+	assert.Equal(t, "", Preview(&zero, 3))
+}
+
+func TestIsEmpty(t *testing.T) {
+	n := Sqrt(2)
+	assert.False(t, n.WithEnd(1).IsEmpty())
+	assert.True(t, n.WithEnd(0).IsEmpty())
+	assert.True(t, n.WithStart(5).WithEnd(5).IsEmpty())
+	assert.False(t, n.WithStart(5).WithEnd(6).IsEmpty())
+	fresh := Sqrt(3)
+	assert.True(t, fresh.WithStart(1000000).WithEnd(10).IsEmpty())
+	assert.Zero(t, fresh.NumComputed())
+	assert.True(t, Sqrt(256).WithStart(2).(FiniteSequence).IsEmpty())
+	assert.False(t, Sqrt(256).(*FiniteNumber).IsEmpty())
+	assert.True(t, zeroNumber.IsEmpty())
+	strided := n.WithEnd(10).Stride(4).(FiniteSequence)
+	assert.False(t, strided.IsEmpty())
+	assert.True(t, strided.FiniteWithStart(9).IsEmpty())
+	filtered := FilterSeq(n.WithEnd(10), func(index, digit int) bool {
+		return digit == 7
+	}).(FiniteSequence)
+	assert.True(t, filtered.IsEmpty())
+	assert.False(t, MapSeq(n.WithEnd(1), func(index, digit int) int {
+		return digit
+	}).(FiniteSequence).IsEmpty())
+}
+
+func TestSqrtNewton(t *testing.T) {
+	radicans := []*big.Rat{
+		big.NewRat(2, 1),
+		big.NewRat(3, 7),
+		big.NewRat(1, 1000),
+		big.NewRat(123456789, 1),
+		big.NewRat(5, 100000000000),
+	}
+	for _, radican := range radicans {
+		expected := SqrtBigRat(radican)
+		actual := SqrtNewton(radican)
+		assert.Equal(t, expected.Exponent(), actual.Exponent())
+		assert.True(
+			t, SequenceEqual(expected.WithEnd(2000), actual.WithEnd(2000)))
+	}
+	assert.Equal(t, "0.5", SqrtNewton(big.NewRat(1, 4)).String())
+	assert.True(t, SqrtNewton(new(big.Rat)).IsZero())
+	assert.Panics(t, func() { SqrtNewton(big.NewRat(-1, 1)) })
+}
+
+func TestNewtonSqrtDigitsExact(t *testing.T) {
+	digits := computeNewtonSqrtDigits(big.NewInt(25), big.NewInt(100))
+	assert.Equal(t, 5, digits())
+	assert.Equal(t, -1, digits())
+	assert.Equal(t, -1, digits())
+	digits = computeNewtonSqrtDigits(big.NewInt(1), big.NewInt(100))
+	assert.Equal(t, 1, digits())
+	assert.Equal(t, -1, digits())
+}
+
+func TestSqrtInBlocksMatchesDigitByDigit(t *testing.T) {
+	radicans := []*big.Rat{
+		big.NewRat(2, 1),
+		big.NewRat(1, 4),
+		big.NewRat(3, 7),
+		big.NewRat(1, 1000000000000000000),
+		big.NewRat(5, 100000000000),
+		big.NewRat(99, 100),
+		big.NewRat(123456789123456789, 1),
+		big.NewRat(10, 1),
+		big.NewRat(1, 10),
+
+		// 12345678.9 squared
+		big.NewRat(15241578750190521, 100),
+	}
+	for _, radican := range radicans {
+		expected := NewNumber(
+			newNRootGenerator(radican.Num(), radican.Denom(), newSqrtManager))
+		actual := NewNumber(newSqrtGenerator(radican.Num(), radican.Denom()))
+		assert.Equal(t, expected.Exponent(), actual.Exponent(), radican)
+		assert.True(
+			t,
+			SequenceEqual(expected.WithEnd(3000), actual.WithEnd(3000)),
+			radican)
+	}
+}
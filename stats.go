@@ -0,0 +1,73 @@
+package sqrt
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	statsGoroutines     atomic.Int64
+	statsNumbersCreated atomic.Int64
+	statsDigitsComputed atomic.Int64
+	statsBytesHeld      atomic.Int64
+)
+
+// Stats reports the resource usage of this package so that long running
+// services can monitor it. This package has no Context type, so Stats
+// covers all Numbers in the process.
+type Stats struct {
+
+	// Goroutines is the number of goroutines computing digits right now.
+	// This package starts no goroutines of its own. Digits get computed
+	// in whatever goroutine asks for them.
+	Goroutines int64
+
+	// NumbersCreated is the number of Numbers created so far that compute
+	// their own digits. Views such as WithSignificant share the digits of
+	// the Number they came from, so they do not count.
+	NumbersCreated int64
+
+	// DigitsComputed is the number of digits computed so far.
+	DigitsComputed int64
+
+	// BytesHeld is the number of bytes of memoized digits that Numbers
+	// still reachable hold. Digits of Numbers that get garbage collected
+	// stop counting some time after collection.
+	BytesHeld int64
+}
+
+// ReadStats returns the current resource usage of this package.
+func ReadStats() Stats {
+	return Stats{
+		Goroutines:     statsGoroutines.Load(),
+		NumbersCreated: statsNumbersCreated.Load(),
+		DigitsComputed: statsDigitsComputed.Load(),
+		BytesHeld:      statsBytesHeld.Load(),
+	}
+}
+
+// String returns s as JSON.
+func (s Stats) String() string {
+	result, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(result)
+}
+
+// ExpvarVar returns a value that shows the current Stats as JSON each
+// time it prints. The returned value satisfies expvar.Var, so
+// expvar.Publish("sqrt", sqrt.ExpvarVar()) publishes the Stats of this
+// package. ExpvarVar returns a fmt.Stringer rather than an expvar.Var so
+// that this package does not import expvar, which registers an HTTP
+// handler as a side effect.
+func ExpvarVar() fmt.Stringer {
+	return statsVar{}
+}
+
+type statsVar struct{}
+
+func (statsVar) String() string {
+	return ReadStats().String()
+}
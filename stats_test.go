@@ -0,0 +1,43 @@
+package sqrt
+
+import (
+	"encoding/json"
+	"expvar"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ expvar.Var = ExpvarVar()
+
+func TestStats(t *testing.T) {
+	before := ReadStats()
+	n := Sqrt(3)
+	n.At(999)
+	after := ReadStats()
+	assert.GreaterOrEqual(t, after.NumbersCreated-before.NumbersCreated, int64(1))
+	assert.GreaterOrEqual(t, after.DigitsComputed-before.DigitsComputed, int64(1000))
+	assert.GreaterOrEqual(t, after.BytesHeld-before.BytesHeld, int64(1000))
+	assert.GreaterOrEqual(t, after.Goroutines, int64(0))
+	runtime.KeepAlive(n)
+}
+
+func TestStatsBytesHeldAfterCollection(t *testing.T) {
+	before := ReadStats().BytesHeld
+	func() {
+		n := Sqrt(5)
+		n.At(99999)
+	}()
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return ReadStats().BytesHeld < before+100000
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExpvarVar(t *testing.T) {
+	var stats Stats
+	assert.NoError(t, json.Unmarshal([]byte(ExpvarVar().String()), &stats))
+	assert.Greater(t, stats.NumbersCreated, int64(0))
+}
@@ -0,0 +1,250 @@
+package sqrt
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+// stridedPart is a view of every stride-th digit of a mantissa starting
+// at position start.
+type stridedPart struct {
+	mantissa mantissa
+	start    int
+	stride   int
+}
+
+// chunksOf returns the digits in all in blocks of size digits along with
+// the position of the first digit in each block. chunksOf panics if size
+// is not positive.
+func chunksOf(all iter.Seq2[int, int], size int) iter.Seq2[int, []int8] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	return func(yield func(index int, chunk []int8) bool) {
+		var chunk []int8
+		first := 0
+		for posit, digit := range all {
+			if len(chunk) == 0 {
+				first = posit
+				chunk = make([]int8, 0, size)
+			}
+			chunk = append(chunk, int8(digit))
+			if len(chunk) == size {
+				if !yield(first, chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+		if len(chunk) > 0 {
+			yield(first, chunk)
+		}
+	}
+}
+
+func newStridedPart(m mantissa, start, stride int) stridedPart {
+	if stride <= 0 {
+		panic("k must be positive")
+	}
+	return stridedPart{mantissa: m, start: start, stride: stride}
+}
+
+func (s *stridedPart) All() iter.Seq2[int, int] {
+	return s.AllInRange(s.start, s.mantissa.maxDigits)
+}
+
+func (s *stridedPart) AllInRange(start, end int) iter.Seq2[int, int] {
+	return func(yield func(index, value int) bool) {
+		for posit := s.firstAtOrAfter(start); posit < end; posit += s.stride {
+			digit := s.mantissa.At(posit)
+			if digit == -1 || !yield(posit, digit) {
+				return
+			}
+		}
+	}
+}
+
+func (s *stridedPart) AllFrom(
+	token ResumeToken) iter.Seq2[ResumeToken, int] {
+	return allFrom(s.AllInRange, s.start, token)
+}
+
+func (s *stridedPart) Values() iter.Seq[int] {
+	return func(yield func(value int) bool) {
+		for _, digit := range s.All() {
+			if !yield(digit) {
+				return
+			}
+		}
+	}
+}
+
+func (s *stridedPart) Chunks(size int) iter.Seq2[int, []int8] {
+	return chunksOf(s.All(), size)
+}
+
+func (s *stridedPart) ValuesBase(pow int) iter.Seq[int] {
+	return valuesBase(s.Values(), pow)
+}
+
+func (s *stridedPart) PackedDigits(max int) []byte {
+	return appendPackedDigits(nil, s.Values(), max)
+}
+
+// String returns the first 16 digits of this view followed by "..." if
+// there are more digits.
+func (s *stridedPart) String() string {
+	return valuesString(s.Values())
+}
+
+func (s *stridedPart) PrimeToStart(ctx context.Context) error {
+	return s.mantissa.PrimeTo(ctx, s.start)
+}
+
+func (s *stridedPart) at(posit int) int {
+	if posit < s.start || (posit-s.start)%s.stride != 0 {
+		return -1
+	}
+	return s.mantissa.At(posit)
+}
+
+func (s *stridedPart) length() int {
+	length := s.mantissa.Len(0)
+	if length <= s.start {
+		return 0
+	}
+	return (length-1-s.start)/s.stride + 1
+}
+
+func (s *stridedPart) backward() iter.Seq2[int, int] {
+	return func(yield func(index, value int) bool) {
+		count := s.length()
+		for posit := s.start + (count-1)*s.stride; count > 0; posit -= s.stride {
+			if !yield(posit, s.mantissa.At(posit)) {
+				return
+			}
+			count--
+		}
+	}
+}
+
+// firstAtOrAfter returns the first position in this view that is at
+// least posit.
+func (s *stridedPart) firstAtOrAfter(posit int) int {
+	if posit <= s.start {
+		return s.start
+	}
+	return s.start + (posit-s.start+s.stride-1)/s.stride*s.stride
+}
+
+func (s *stridedPart) Start() int {
+	return s.start
+}
+
+func (s *stridedPart) withStart(start int) stridedPart {
+	result := *s
+	result.start = s.firstAtOrAfter(start)
+	return result
+}
+
+func (s *stridedPart) withEnd(end int) stridedPart {
+	result := *s
+	result.mantissa = result.mantissa.WithMaxDigits(end)
+	return result
+}
+
+func (s *stridedPart) withStride(k int) stridedPart {
+	return newStridedPart(s.mantissa, s.start, s.stride*k)
+}
+
+type stridedSequence struct {
+	stridedPart
+}
+
+func (s *stridedSequence) WithStart(start int) Sequence {
+	result := s.withStart(start)
+	if result == s.stridedPart {
+		return s
+	}
+	return &stridedSequence{result}
+}
+
+func (s *stridedSequence) WithEnd(end int) FiniteSequence {
+	return &finiteStridedSequence{s.withEnd(end)}
+}
+
+func (s *stridedSequence) Stride(k int) Sequence {
+	return &stridedSequence{s.withStride(k)}
+}
+
+func (s *stridedSequence) private() {
+}
+
+type finiteStridedSequence struct {
+	stridedPart
+}
+
+func (f *finiteStridedSequence) WithStart(start int) Sequence {
+	return f.FiniteWithStart(start)
+}
+
+func (f *finiteStridedSequence) FiniteWithStart(start int) FiniteSequence {
+	result := f.withStart(start)
+	if result == f.stridedPart {
+		return f
+	}
+	return &finiteStridedSequence{result}
+}
+
+func (f *finiteStridedSequence) WithEnd(end int) FiniteSequence {
+	result := f.withEnd(end)
+	if result == f.stridedPart {
+		return f
+	}
+	return &finiteStridedSequence{result}
+}
+
+func (f *finiteStridedSequence) Stride(k int) Sequence {
+	return &finiteStridedSequence{f.withStride(k)}
+}
+
+func (f *finiteStridedSequence) At(posit int) int {
+	return f.at(posit)
+}
+
+func (f *finiteStridedSequence) Len() int {
+	return f.length()
+}
+
+func (f *finiteStridedSequence) Reversed() FiniteSequence {
+	return reversed(f)
+}
+
+func (f *finiteStridedSequence) Backward() iter.Seq2[int, int] {
+	return f.backward()
+}
+
+func (f *finiteStridedSequence) IsEmpty() bool {
+	return f.start >= f.mantissa.maxDigits || f.mantissa.At(f.start) == -1
+}
+
+func (f *finiteStridedSequence) End() (int, bool) {
+	return f.mantissa.End()
+}
+
+func (f *finiteStridedSequence) AllInRangeBackward(
+	start, end int) iter.Seq2[int, int] {
+	return f.FiniteWithStart(start).WithEnd(end).Backward()
+}
+
+func (f *finiteStridedSequence) PrimeToEnd(ctx context.Context) error {
+	return f.mantissa.PrimeToEnd(ctx)
+}
+
+func (f *finiteStridedSequence) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, f)
+}
+
+func (f *finiteStridedSequence) private() {
+}
@@ -0,0 +1,79 @@
+package sqrt
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStride(t *testing.T) {
+	s := Sqrt(2).Stride(3)
+	var positions, values []int
+	for posit, digit := range s.All() {
+		positions = append(positions, posit)
+		values = append(values, digit)
+		if len(positions) == 5 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 3, 6, 9, 12}, positions)
+	assert.Equal(t, []int{1, 4, 3, 2, 3}, values)
+	assert.Equal(t, "14323581", AsString(s.WithEnd(22)))
+	assert.Equal(t, "323581", AsString(s.WithStart(4).WithEnd(22)))
+	assert.Equal(t, "13388", AsString(s.Stride(2).WithEnd(25)))
+	assert.Equal(t, "4120", AsString(Sqrt(2).WithStart(1).Stride(4).WithEnd(16)))
+	assert.NoError(t, s.PrimeToStart(context.Background()))
+}
+
+func TestStrideInRange(t *testing.T) {
+	s := Sqrt(2).Stride(3)
+	var positions []int
+	for posit := range s.AllInRange(4, 16) {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{6, 9, 12, 15}, positions)
+	assert.Equal(t, []byte{0x14, 0x32}, s.PackedDigits(4))
+}
+
+func TestStrideFinite(t *testing.T) {
+	n := Sqrt(2).WithSignificant(10)
+	s := n.Stride(2).(FiniteSequence)
+	assert.Equal(t, "11236", AsString(s))
+	assert.Equal(t, 5, s.Len())
+	assert.Equal(t, 2, s.At(4))
+	assert.Equal(t, -1, s.At(3))
+	assert.Equal(t, -1, s.At(10))
+	var positions []int
+	for posit := range s.Backward() {
+		positions = append(positions, posit)
+	}
+	assert.Equal(t, []int{8, 6, 4, 2, 0}, positions)
+	assert.Equal(t, "236", AsString(s.FiniteWithStart(3)))
+	assert.Equal(t, 3, s.FiniteWithStart(3).Len())
+	assert.Same(t, s, s.FiniteWithStart(0))
+	assert.Equal(t, "11", AsString(s.WithEnd(3)))
+	assert.Equal(t, 1, Sqrt(256).WithEnd(100).Stride(2).(FiniteSequence).Len())
+	assert.Equal(t, 0, zeroNumber.Stride(2).(FiniteSequence).Len())
+	assert.Equal(
+		t, "126", AsString(Sqrt(2).WithEnd(10).Stride(4).(FiniteSequence)))
+	assert.Empty(t, slices.Collect(s.FiniteWithStart(9).Values()))
+}
+
+func TestStridePanics(t *testing.T) {
+	assert.Panics(t, func() { Sqrt(2).Stride(0) })
+	assert.Panics(t, func() { Sqrt(2).WithStart(3).Stride(-1) })
+}
+
+func TestStrideChunks(t *testing.T) {
+	s := Sqrt(2).Stride(3).WithEnd(22)
+	var indexes []int
+	var chunks [][]int8
+	for index, chunk := range s.Chunks(3) {
+		indexes = append(indexes, index)
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, []int{0, 9, 18}, indexes)
+	assert.Equal(t, [][]int8{{1, 4, 3}, {2, 3, 5}, {8, 1}}, chunks)
+}
@@ -0,0 +1,83 @@
+package sqrt
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+)
+
+var twenty = big.NewInt(20)
+
+// WriteSqrtWorksheet writes the first steps steps of computing the square
+// root of radican by hand to w in the style of long division. Each step
+// brings down the next group of two digits of radican, finds the largest
+// digit d such that (20 * root so far + d) * d is no more than the current
+// value, and subtracts that product to get the remainder. The root so far
+// is the root digits without a decimal point; the header tells where the
+// decimal point goes. WriteSqrtWorksheet stops early if the square root
+// has fewer than steps digits. WriteSqrtWorksheet returns the first error
+// that w returns. WriteSqrtWorksheet panics if steps is negative, if the
+// denominator of radican is not positive, or if the numerator of radican
+// is negative.
+func WriteSqrtWorksheet(w io.Writer, radican *big.Rat, steps int) error {
+	if steps < 0 {
+		panic("steps must be non-negative")
+	}
+	checkNumDenom(radican.Num(), radican.Denom())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if radican.Sign() == 0 {
+		fmt.Fprintf(tw, "sqrt(%s) = 0\n", radican.RatString())
+		return tw.Flush()
+	}
+	groups, exp := computeGroupsFromRational(
+		radican.Num(), radican.Denom(), oneHundred)
+	fmt.Fprintf(
+		tw,
+		"sqrt(%s) = 0.<root> * 10^%d\n",
+		radican.RatString(),
+		exp)
+	fmt.Fprintln(
+		tw,
+		"Step\tGroup\tCurrent\tDivisor\tDigit\tSubtract\tRemainder\tRoot\t")
+	var root, remainder, current, divisor, product, groupHolder big.Int
+	for step := 1; step <= steps; step++ {
+		group := groups(&groupHolder)
+		if group == nil {
+			if remainder.Sign() == 0 {
+				break
+			}
+			group = groupHolder.SetInt64(0)
+		}
+		current.Mul(&remainder, oneHundred).Add(&current, group)
+
+		// Find the largest digit such that
+		// (20 * root + digit) * digit <= current.
+		divisor.Mul(&root, twenty)
+		digit := int64(0)
+		for digit < 9 {
+			product.Add(&divisor, big.NewInt(digit+1))
+			product.Mul(&product, big.NewInt(digit+1))
+			if product.Cmp(&current) > 0 {
+				break
+			}
+			digit++
+		}
+		divisor.Add(&divisor, big.NewInt(digit))
+		product.Mul(&divisor, big.NewInt(digit))
+		remainder.Sub(&current, &product)
+		root.Mul(&root, ten).Add(&root, big.NewInt(digit))
+		fmt.Fprintf(
+			tw,
+			"%d\t%02d\t%s\t%s\t%d\t%s\t%s\t%s\t\n",
+			step,
+			group,
+			&current,
+			&divisor,
+			digit,
+			&product,
+			&remainder,
+			&root)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,68 @@
+package sqrt
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSqrtWorksheet(t *testing.T) {
+	var sb strings.Builder
+	err := WriteSqrtWorksheet(&sb, big.NewRat(2, 1), 4)
+	assert.NoError(t, err)
+	expected := `sqrt(2) = 0.<root> * 10^1
+  Step  Group  Current  Divisor  Digit  Subtract  Remainder  Root
+     1     02        2        1      1         1          1     1
+     2     00      100       24      4        96          4    14
+     3     00      400      281      1       281        119   141
+     4     00    11900     2824      4     11296        604  1414
+`
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestWriteSqrtWorksheetExact(t *testing.T) {
+	var sb strings.Builder
+	err := WriteSqrtWorksheet(&sb, big.NewRat(152399025, 1), 10)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	assert.Equal(t, "sqrt(152399025) = 0.<root> * 10^5", lines[0])
+	assert.Len(t, lines, 7)
+	assert.Equal(
+		t,
+		[]string{"5", "25", "123425", "24685", "5", "123425", "0", "12345"},
+		strings.Fields(lines[6]))
+}
+
+func TestWriteSqrtWorksheetRootDigits(t *testing.T) {
+	var sb strings.Builder
+	err := WriteSqrtWorksheet(&sb, big.NewRat(3, 7), 50)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	assert.Equal(
+		t, AsString(SqrtRat(3, 7).WithSignificant(50)), fields[len(fields)-1])
+	assert.Equal(t, "sqrt(3/7) = 0.<root> * 10^0", lines[0])
+}
+
+func TestWriteSqrtWorksheetZero(t *testing.T) {
+	var sb strings.Builder
+	assert.NoError(t, WriteSqrtWorksheet(&sb, new(big.Rat), 10))
+	assert.Equal(t, "sqrt(0) = 0\n", sb.String())
+}
+
+func TestWriteSqrtWorksheetError(t *testing.T) {
+	w := &failingWriter{limit: 10}
+	err := WriteSqrtWorksheet(w, big.NewRat(2, 1), 10)
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestWriteSqrtWorksheetPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		WriteSqrtWorksheet(&strings.Builder{}, big.NewRat(2, 1), -1)
+	})
+	assert.Panics(t, func() {
+		WriteSqrtWorksheet(&strings.Builder{}, big.NewRat(-2, 1), 3)
+	})
+}